@@ -0,0 +1,33 @@
+package main
+
+import (
+	"go-project/internal/config"
+	"go-project/internal/db/mysql"
+	"go-project/internal/db/seeder"
+	"log"
+)
+
+func main() {
+	cfg := config.LoadConfig()
+
+	db, err := mysql.NewPgConnection(
+		cfg.DBUser,
+		cfg.DBPassword,
+		cfg.DBHost,
+		cfg.DBName,
+		cfg.DBPort,
+	)
+
+	if err != nil {
+		log.Fatalf("Failed to connect to DB: %v", err)
+	}
+
+	if err := mysql.AutoMigrate(db); err != nil {
+		log.Fatalf("Auto-migrate failed: %v", err)
+	}
+
+	if err := seeder.Run(db, cfg.SeedDir); err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+	log.Println("Seeding finished")
+}
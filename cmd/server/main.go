@@ -3,6 +3,7 @@ package main
 import (
 	"go-project/internal/config"
 	"go-project/internal/db/mysql"
+	"go-project/internal/db/seeder"
 	"go-project/internal/delivery/http"
 	"log"
 
@@ -39,6 +40,12 @@ func main() {
 		log.Fatalf("Auto-migrate failed: %v", err)
 	}
 
+	if cfg.SeedOnStartup {
+		if err := seeder.Run(db, cfg.SeedDir); err != nil {
+			log.Fatalf("Seeding failed: %v", err)
+		}
+	}
+
 	port := cfg.ServerPort
 	r := http.NewRouter(cfg, db)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
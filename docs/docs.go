@@ -15,45 +15,104 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/get-users": {
+        "/api/2fa": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ambil semua pengguna dari database",
+                "description": "Ambil apakah autentikasi dua faktor aktif dan sisa kode pemulihan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "2fa"
                 ],
-                "summary": "Ambil semua user",
-                "responses": {}
+                "summary": "Status autentikasi dua faktor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TwoFactorStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
             }
         },
-        "/api/profile": {
-            "get": {
+        "/api/2fa/confirm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ambil data user (requires JWT token)",
+                "description": "Aktifkan autentikasi dua faktor dengan kode TOTP dari aplikasi autentikator. Respons berisi kode pemulihan yang hanya ditampilkan sekali ini",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "2fa"
                 ],
-                "summary": "Get user profile",
-                "responses": {}
+                "summary": "Aktifkan autentikasi dua faktor",
+                "parameters": [
+                    {
+                        "description": "Kode TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/auth/login": {
+        "/api/2fa/disable": {
             "post": {
-                "description": "Otentikasi pengguna dan dapatkan token JWT",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Matikan autentikasi dua faktor dengan kata sandi dan kode TOTP atau kode pemulihan",
                 "consumes": [
                     "application/json"
                 ],
@@ -61,26 +120,95 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "2fa"
                 ],
-                "summary": "Login user",
+                "summary": "Nonaktifkan autentikasi dua faktor",
                 "parameters": [
                     {
-                        "description": "Login request",
+                        "description": "Disable two-factor request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.LoginRequest"
+                            "$ref": "#/definitions/handler.DisableTwoFactorRequest"
                         }
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/auth/register": {
+        "/api/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buat secret TOTP baru beserta URI otpauth dan kode QR (PNG data URI) untuk dipindai aplikasi autentikator. Belum aktif sampai dikonfirmasi lewat /api/2fa/confirm",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2fa"
+                ],
+                "summary": "Daftarkan autentikasi dua faktor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TwoFactorEnrollment"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/2fa/recovery-codes": {
             "post": {
-                "description": "Buat akun pengguna baru",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ganti semua kode pemulihan dengan yang baru setelah memeriksa kode TOTP. Kode lama tidak berlaku lagi",
                 "consumes": [
                     "application/json"
                 ],
@@ -88,54 +216,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "2fa"
                 ],
-                "summary": "Daftarkan pengguna baru",
+                "summary": "Buat ulang kode pemulihan",
                 "parameters": [
                     {
-                        "description": "Register request",
+                        "description": "Kode TOTP",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RegisterRequest"
+                            "$ref": "#/definitions/handler.TwoFactorCodeRequest"
                         }
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/cash-balance": {
+        "/api/cash/accounts": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghitung dan menampilkan saldo kas untuk tanggal tertentu",
+                "description": "Menampilkan akun tempat uang disimpan: kas laci (cash), rekening bank (bank) atau dompet digital (ewallet)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Cash"
                 ],
-                "summary": "Lihat saldo kas harian",
+                "summary": "Ambil daftar akun kas",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tanggal (YYYY-MM-DD)",
-                        "name": "date",
+                        "type": "boolean",
+                        "description": "Sertakan akun yang diarsipkan",
+                        "name": "include_archived",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data saldo kas harian",
+                        "description": "List akun kas",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -144,125 +304,166 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/cash-categories": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan semua kategori transaksi kas (uang masuk / keluar)",
+                "description": "Tambah akun kas baru beserta saldo awalnya",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Cash"
                 ],
-                "summary": "Ambil daftar kategori kas",
+                "summary": "Tambah akun kas",
+                "parameters": [
+                    {
+                        "description": "Data akun kas",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateAccountRequest"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "List kategori kas",
+                    "201": {
+                        "description": "Akun kas yang dibuat",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/cash-transactions": {
-            "get": {
+        "/api/cash/accounts/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ambil daftar transaksi kas berdasarkan rentang tanggal",
+                "description": "Ubah nama, jenis, penyedia atau nomor akun kas. Saldo awal diubah lewat /api/cash/opening-balance",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Cash"
                 ],
-                "summary": "Ambil daftar transaksi kas",
+                "summary": "Ubah akun kas",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tanggal mulai (YYYY-MM-DD)",
-                        "name": "start",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "ID akun kas",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Tanggal akhir (YYYY-MM-DD)",
-                        "name": "end",
-                        "in": "query"
+                        "description": "Data akun kas",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateAccountRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List transaksi",
+                        "description": "Akun kas yang diubah",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/cash/accounts/{id}/archive": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Tambah transaksi uang masuk atau keluar (requires JWT token)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Arsipkan akun kas agar tidak dapat menerima transaksi baru; riwayat dan saldonya tetap tersimpan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Cash"
                 ],
-                "summary": "Tambah transaksi kas",
+                "summary": "Arsipkan akun kas",
                 "parameters": [
                     {
-                        "description": "Data transaksi kas",
-                        "name": "transaction",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CashTransaction"
-                        }
+                        "type": "integer",
+                        "description": "ID akun kas",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Transaction recorded successfully",
+                    "200": {
+                        "description": "Akun kas yang diarsipkan",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -270,111 +471,3655 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.CashCategory": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "transactions": {
-                    "description": "Relasi",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.CashTransaction"
+        },
+        "/api/cash/balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                },
+                ],
+                "description": "Menghitung dan menampilkan saldo kas untuk tanggal tertentu, per akun atau gabungan semua akun termasuk yang diarsipkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Lihat saldo kas harian",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data saldo kas harian",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/balance/recalculate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hitung ulang saldo harian dari transaksi mulai tanggal tertentu hingga hari terakhir. Periode yang sudah ditutup dilewati tanpa diubah; hari sesudahnya dilanjutkan dari saldo akhir periode tersebut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Hitung ulang saldo kas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saldo berhasil dihitung ulang",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan semua kategori transaksi kas (uang masuk / keluar)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ambil daftar kategori kas",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan kategori yang diarsipkan",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List kategori kas",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tambah kategori untuk uang masuk (in), uang keluar (out) atau keduanya (both)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Tambah kategori kas",
+                "parameters": [
+                    {
+                        "description": "Data kategori",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Kategori yang dibuat",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah nama, jenis atau keterangan kategori. Jenis tidak dapat dipersempit bila masih ada transaksi jenis lain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ubah kategori kas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID kategori",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data kategori",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kategori yang diubah",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/categories/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Arsipkan kategori agar tidak dapat dipakai transaksi baru; transaksi lama tetap memakai kategori ini. Kategori sistem tidak dapat diarsipkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Arsipkan kategori kas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID kategori",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kategori yang diarsipkan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/counts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar hitung kas fisik, terbaru lebih dulu, dapat difilter per akun dan rentang tanggal",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Daftar hitung kas fisik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar hitung kas",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Catat jumlah lembar dan keping uang di laci akun kas tunai per pecahan rupiah (100000, 50000, 20000, 10000, 5000, 2000, 1000, 500, 200, 100) pada akhir hari. Total hitungan dibandingkan dengan saldo akhir buku hari itu; selisihnya baru dibukukan setelah hitungan dikonfirmasi. Tanggal kosong berarti hari ini",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Catat hitung kas fisik",
+                "parameters": [
+                    {
+                        "description": "Hitungan per pecahan",
+                        "name": "count",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CashCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Hitung kas berstatus draft",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/counts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Detail hitung kas beserta rincian pecahan dan transaksi selisih bila sudah dikonfirmasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Detail hitung kas fisik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID hitung kas",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hitung kas",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/counts/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Konfirmasi hitung kas sebagai penutupan hari akun tersebut. Selisih dihitung ulang terhadap buku saat ini; kelebihan kas dicatat sebagai transaksi masuk dan kekurangan sebagai transaksi keluar berkategori \"Selisih Kas\" pada akhir hari yang dihitung, sehingga saldo akhir buku sama dengan hitungan. Satu akun hanya dapat ditutup sekali per hari",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Konfirmasi hitung kas dan tutup hari",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID hitung kas",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hitung kas yang dikonfirmasi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/opening-balance": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atur saldo awal akun kas; saldo awal dan akhir setiap hari yang tersimpan pada akun tersebut ikut disesuaikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Atur saldo awal akun kas",
+                "parameters": [
+                    {
+                        "description": "Saldo awal",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OpeningBalanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data akun kas",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/periods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar periode yang pernah ditutup, terbaru lebih dulu, termasuk yang sudah dibuka kembali",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Daftar periode tutup buku",
+                "responses": {
+                    "200": {
+                        "description": "Daftar periode",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/periods/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kunci rentang tanggal pada semua akun sehingga transaksi di dalamnya tidak dapat ditambah, diubah atau dibatalkan, dan simpan potret saldo awal dan akhir setiap akun serta total per kategori periode tersebut. Periode tidak boleh bertumpang tindih dengan periode lain yang masih ditutup",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Tutup buku periode",
+                "parameters": [
+                    {
+                        "description": "Periode yang ditutup",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ClosePeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Periode yang ditutup beserta potretnya",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/periods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Periode beserta potret saldo per akun, total per kategori dan riwayat tutup dan buka kembali",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Detail periode tutup buku",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID periode",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Periode",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/periods/{id}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buka kunci periode yang sudah ditutup; hanya untuk owner dan admin. Alasan wajib diisi dan dicatat bersama penggunanya. Potret saldo periode tetap tersimpan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Buka kembali periode tutup buku",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID periode",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan membuka kembali",
+                        "name": "reopen",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReopenPeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Periode yang dibuka kembali",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reconciliations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar rekonsiliasi, terbaru lebih dulu, dapat difilter per akun kas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Daftar rekonsiliasi bank",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas",
+                        "name": "account_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar rekonsiliasi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buka rekonsiliasi akun kas untuk satu periode dengan saldo akhir menurut rekening koran. Baris mutasi boleh dikirim langsung (nominal bertanda: positif masuk, negatif keluar) atau dimuat kemudian dari file mutasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Buat rekonsiliasi bank",
+                "parameters": [
+                    {
+                        "description": "Data rekonsiliasi",
+                        "name": "reconciliation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateReconciliationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Rekonsiliasi yang dibuat",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reconciliations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saldo buku dan rekening koran pada akhir periode beserta penjelasan selisihnya: pasangan yang cocok, baris mutasi yang belum tercatat di buku, transaksi yang belum muncul di rekening koran, dan selisih yang belum terjelaskan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Laporan rekonsiliasi bank",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID rekonsiliasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reconciliations/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tutup rekonsiliasi yang selisihnya sudah terjelaskan seluruhnya. Transaksi yang cocok ditandai sudah direkonsiliasi dan tidak dapat diubah atau dibatalkan lagi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Selesaikan rekonsiliasi bank",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID rekonsiliasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reconciliations/{id}/lines/{line_id}/match": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pasangkan satu baris mutasi dengan transaksi akun yang sama, menggantikan pasangan sebelumnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Cocokkan baris mutasi secara manual",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID rekonsiliasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID baris mutasi",
+                        "name": "line_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaksi pasangan",
+                        "name": "match",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MatchLineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lepaskan transaksi yang dipasangkan dengan satu baris mutasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Lepas pasangan baris mutasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID rekonsiliasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID baris mutasi",
+                        "name": "line_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reconciliations/{id}/match": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cocokkan baris mutasi yang belum cocok dengan transaksi akun: pertama menurut referensi bank, lalu nominal sama pada tanggal yang sama, lalu pencocokan fuzzy menurut kedekatan nominal, tanggal dan keterangan dalam batas toleransi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Cocokkan mutasi bank otomatis",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID rekonsiliasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Toleransi selisih tanggal dalam hari (1-31), bawaan 3",
+                        "name": "date_tolerance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Toleransi selisih nominal, bawaan 0",
+                        "name": "amount_tolerance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reconciliations/{id}/statement": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Muat baris file mutasi bank (OFX, SWIFT MT940 atau ISO 20022 CAMT.053) yang bertanggal dalam periode rekonsiliasi. Baris yang referensinya sudah dimuat dilewati; saldo akhir pada file, bila ada, menggantikan saldo rekening koran",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Muat mutasi bank ke rekonsiliasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID rekonsiliasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File mutasi bank",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ofx",
+                            "mt940",
+                            "camt053"
+                        ],
+                        "type": "string",
+                        "description": "Format file; kosong untuk deteksi otomatis",
+                        "name": "format",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reports/cash-book/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unduh buku kas untuk satu periode. xlsx: lembar Ringkasan per bulan dan satu lembar per bulan berisi saldo awal, transaksi dengan saldo berjalan dan total berupa rumus. pdf: \"Laporan Buku Kas\" siap cetak dengan saldo awal, transaksi dan saldo berjalan, rekap per kategori, saldo akhir serta kolom tanda tangan Bendahara dan Ketua",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ekspor buku kas ke Excel atau PDF",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD), bawaan awal bulan ini",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD), bawaan hari ini",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "xlsx",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Format file, bawaan xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama Bendahara untuk kolom tanda tangan (pdf)",
+                        "name": "bendahara",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama Ketua untuk kolom tanda tangan (pdf)",
+                        "name": "ketua",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File buku kas",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reports/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total masuk, keluar, selisih dan saldo berjalan per hari/minggu/bulan/tahun, dengan rincian per kategori. Transfer antar akun tidak dihitung sebagai pemasukan atau pengeluaran. Paling banyak 366 periode per laporan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Laporan ringkasan kas per periode",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD), bawaan awal bulan ini",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD), bawaan hari ini",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "year"
+                        ],
+                        "type": "string",
+                        "description": "Periode pengelompokan",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan rincian kategori per periode",
+                        "name": "by_category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan ringkasan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/reports/summary/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unduh laporan ringkasan per periode sebagai CSV: saldo awal, satu baris per periode (didahului rincian kategori jika by_category) dan baris total dengan saldo akhir",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ekspor laporan ringkasan kas ke CSV",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD), bawaan awal bulan ini",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD), bawaan hari ini",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "year"
+                        ],
+                        "type": "string",
+                        "description": "Periode pengelompokan",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan rincian kategori per periode",
+                        "name": "by_category",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "comma",
+                            "semicolon",
+                            "tab",
+                            "pipe"
+                        ],
+                        "type": "string",
+                        "description": "Pemisah kolom, bawaan comma",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "plain",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Format angka: plain (1234567.50) atau id (1.234.567,50)",
+                        "name": "number_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File CSV",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/statements/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Impor file mutasi bank (OFX, SWIFT MT940 atau ISO 20022 CAMT.053) menjadi transaksi pada akun kas yang dipilih. Baris yang referensi banknya sudah pernah diimpor dilewati (skipped); baris tanpa kategori untuk arahnya, bernominal nol atau bertanggal di masa depan dilaporkan sebagai unmatched",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Impor mutasi rekening bank",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File mutasi bank",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas tujuan",
+                        "name": "account_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ofx",
+                            "mt940",
+                            "camt053"
+                        ],
+                        "type": "string",
+                        "description": "Format file; kosong untuk deteksi otomatis",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID kategori untuk dana masuk",
+                        "name": "in_category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID kategori untuk dana keluar",
+                        "name": "out_category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya pratinjau, tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hasil pratinjau atau impor tanpa transaksi baru",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StatementImportResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Hasil impor",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StatementImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ambil daftar transaksi kas per halaman (cursor) dengan filter. Ringkasan total masuk/keluar dihitung dari seluruh hasil filter; transfer antar akun tidak dihitung kecuali kind=transfer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ambil daftar transaksi kas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in",
+                            "out"
+                        ],
+                        "type": "string",
+                        "description": "Jenis transaksi",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "regular",
+                            "transfer"
+                        ],
+                        "type": "string",
+                        "description": "Jenis pencatatan",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID kategori",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metode pembayaran",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID pengguna pembuat",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nominal minimum",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nominal maksimum",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari di keterangan",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "date_asc",
+                            "date_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Urutan",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (maks. 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya, dengan urutan (sort) yang sama",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List transaksi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tambah transaksi uang masuk atau keluar pada akun kas tertentu (requires JWT token). transaction_date boleh diisi tanggal lampau; jika kosong dipakai waktu sekarang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Tambah transaksi kas",
+                "parameters": [
+                    {
+                        "description": "Data transaksi kas",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transaction recorded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/transactions/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unduh seluruh transaksi yang cocok dengan filter sebagai CSV tanpa paginasi. Baris dikirim bertahap langsung dari database sehingga ekspor data setahun tidak dimuat sekaligus ke memori",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ekspor transaksi kas ke CSV",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas; kosong untuk semua akun",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in",
+                            "out"
+                        ],
+                        "type": "string",
+                        "description": "Jenis transaksi",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "regular",
+                            "transfer"
+                        ],
+                        "type": "string",
+                        "description": "Jenis pencatatan",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID kategori",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metode pembayaran",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID pengguna pembuat",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nominal minimum",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nominal maksimum",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari di keterangan",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "date_asc",
+                            "date_desc",
+                            "amount_asc",
+                            "amount_desc"
+                        ],
+                        "type": "string",
+                        "description": "Urutan",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "comma",
+                            "semicolon",
+                            "tab",
+                            "pipe"
+                        ],
+                        "type": "string",
+                        "description": "Pemisah kolom, bawaan comma",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "plain",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Format angka: plain (1234567.50) atau id (1.234.567,50)",
+                        "name": "number_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File CSV",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/transactions/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Impor banyak transaksi sekaligus dari file CSV berheader. mapping berisi JSON yang memetakan field transaksi ke nama kolom, mis. {\"date\":\"Tanggal\",\"type\":\"Jenis\",\"amount\":\"Nominal\",\"category\":\"Kategori\",\"description\":\"Keterangan\"}. Tanpa kolom jenis, nominal negatif dianggap keluar; atau petakan kolom in dan out terpisah. Kategori dan akun boleh berupa nama atau ID. Setiap baris divalidasi dan dilaporkan; dengan dry_run tidak ada yang disimpan, tanpa dry_run semua baris yang valid disimpan dalam satu transaksi database dan saldo dihitung ulang sekali",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Impor transaksi kas dari CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pemetaan kolom (JSON)",
+                        "name": "mapping",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID akun kas untuk baris tanpa kolom akun",
+                        "name": "account_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya validasi, tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "comma",
+                            "semicolon",
+                            "tab",
+                            "pipe"
+                        ],
+                        "type": "string",
+                        "description": "Pemisah kolom, bawaan comma",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "plain",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Format angka: plain (1234567.50) atau id (1.234.567,50)",
+                        "name": "number_format",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "YYYY-MM-DD",
+                            "DD/MM/YYYY",
+                            "DD-MM-YYYY",
+                            "MM/DD/YYYY"
+                        ],
+                        "type": "string",
+                        "description": "Format tanggal, bawaan YYYY-MM-DD",
+                        "name": "date_format",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hasil validasi (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Hasil impor",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/transactions/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah nominal, jenis, kategori atau keterangan transaksi dan hitung ulang saldo hari itu serta hari-hari berikutnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Ubah transaksi kas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID transaksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data transaksi kas",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaksi yang sudah diubah",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/transactions/{id}/void": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Batalkan transaksi dengan membuat transaksi pembalik pada tanggal yang sama; transaksi asli tetap tersimpan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Batalkan transaksi kas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID transaksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan pembatalan",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.VoidTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaksi pembalik",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cash/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pindahkan dana antar akun kas (mis. setor kas laci ke bank). Dicatat sebagai pasangan transaksi keluar/masuk yang saling terhubung dan tidak dihitung sebagai pemasukan atau pengeluaran. Membatalkan salah satu transaksi akan membatalkan keduanya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cash"
+                ],
+                "summary": "Transfer antar akun kas",
+                "parameters": [
+                    {
+                        "description": "Data transfer",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transfer yang dicatat",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/get-users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ambil semua pengguna dari database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Ambil semua user",
+                "responses": {
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ambil data user (requires JWT token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user profile",
+                "responses": {}
+            }
+        },
+        "/api/security-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ambil log keamanan login (berhasil, gagal, diblokir, terkunci, gagal dua faktor), terbaru lebih dulu. Untuk halaman berikutnya kirim next_before_id sebagai before_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Log keamanan",
+                "parameters": [
+                    {
+                        "enum": [
+                            "login_success",
+                            "login_failure",
+                            "login_blocked",
+                            "login_locked",
+                            "two_factor_failure"
+                        ],
+                        "type": "string",
+                        "description": "Jenis event",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email yang dicoba",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IP klien",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sejak waktu (RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sampai sebelum waktu (RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hanya event dengan ID lebih kecil",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah event (default 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar event",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah role pengguna menjadi owner, admin, cashier atau viewer. Hanya owner yang dapat memberi atau mencabut role owner, dan owner terakhir tidak dapat diturunkan. Semua sesi pengguna tersebut dicabut, sehingga role baru berlaku sejak login berikutnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Ubah role user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User yang diubah",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Kirim tautan atur ulang kata sandi ke email pengguna. Respons selalu sama, baik alamat tidak terdaftar, permintaan yang terlalu rapat untuk alamat yang sama atau terlalu banyak dari satu IP (yang diabaikan tanpa mengirim email), maupun email yang gagal terkirim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Lupa kata sandi",
+                "parameters": [
+                    {
+                        "description": "Forgot password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Otentikasi pengguna dan dapatkan access token JWT berumur pendek (token) beserta refresh token. Tergantung konfigurasi, akun yang emailnya belum terverifikasi ditolak atau hanya mendapat akses baca. Bila autentikasi dua faktor aktif, respons berisi two_factor_required dan challenge (lihat domain.TwoFactorChallenge) yang diselesaikan lewat /auth/login/2fa. Terlalu banyak percobaan gagal untuk satu akun atau IP mengunci login sementara, makin lama untuk tiap kegagalan berikutnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenPair"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Tukar challenge dari /auth/login dengan pasangan token, memakai kode TOTP dari aplikasi autentikator atau kode pemulihan yang belum dipakai. Challenge berumur pendek dan hangus setelah beberapa kode salah",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Selesaikan login dua faktor",
+                "parameters": [
+                    {
+                        "description": "Complete login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CompleteLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenPair"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cabut access token yang dipakai dan refresh token sesi ini. Dengan all_sessions, semua sesi pengguna dicabut, termasuk access token sesi lain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Logout request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Tukar refresh token dengan pasangan token baru; refresh token lama tidak berlaku lagi. Refresh token yang dipakai ulang dianggap bocor dan seluruh sesi login tersebut dicabut",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Perbarui token",
+                "parameters": [
+                    {
+                        "description": "Refresh request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenPair"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Buat akun pengguna baru. Akun belum terverifikasi sampai tautan verifikasi yang dikirim ke emailnya dibuka",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Daftarkan pengguna baru",
+                "parameters": [
+                    {
+                        "description": "Register request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/auth/resend-verification": {
+            "post": {
+                "description": "Kirim tautan verifikasi baru ke alamat yang belum terverifikasi. Respons selalu sama, baik alamat tidak terdaftar, sudah terverifikasi, permintaan yang terlalu rapat atau terlalu banyak dari satu IP (yang diabaikan tanpa mengirim email), maupun email yang gagal terkirim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Kirim ulang email verifikasi",
+                "parameters": [
+                    {
+                        "description": "Resend verification request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Tetapkan kata sandi baru dengan token dari email lupa kata sandi. Token hanya berlaku sekali, dan semua sesi lain harus login ulang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Atur ulang kata sandi",
+                "parameters": [
+                    {
+                        "description": "Reset password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "get": {
+                "description": "Tandai alamat email terverifikasi dengan token dari tautan di email verifikasi. Membuka tautan yang sama lagi tidak menghasilkan galat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verifikasi email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token verifikasi",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.CashAccount": {
+            "type": "object",
+            "properties": {
+                "account_number": {
+                    "type": "string"
+                },
+                "archived_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_balance": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CashCategory": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "system_key": {
+                    "description": "SystemKey marks a category the application books its own entries\nunder, one of the SystemCategory keys; it is nil for the others.",
+                    "type": "string"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CashTransaction"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CashTransaction": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/domain.CashAccount"
+                },
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "bank_reference": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/domain.CashCategory"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "reconciled_at": {
+                    "type": "string"
+                },
+                "reconciliation_id": {
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "integer"
+                },
+                "reversal_of_id": {
+                    "type": "integer"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
                 "type": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/domain.User"
+                },
+                "void_reason": {
+                    "type": "string"
+                },
+                "voided_at": {
+                    "type": "string"
+                },
+                "voided_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportRowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/domain.CashTransaction"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.Reconciliation": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/domain.CashAccount"
+                },
+                "account_id": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "completed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ReconciliationLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "statement_balance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReconciliationLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_type": {
+                    "type": "string"
+                },
+                "reconciliation_id": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReconciliationMatch": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "$ref": "#/definitions/domain.ReconciliationLine"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/domain.CashTransaction"
+                }
+            }
+        },
+        "domain.ReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "book_balance": {
+                    "type": "string"
+                },
+                "difference": {
+                    "type": "string"
+                },
+                "matched": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ReconciliationMatch"
+                    }
+                },
+                "matched_difference": {
+                    "type": "string"
+                },
+                "reconciliation": {
+                    "$ref": "#/definitions/domain.Reconciliation"
+                },
+                "statement_balance": {
+                    "type": "string"
+                },
+                "unexplained_difference": {
+                    "type": "string"
+                },
+                "unmatched_book_total": {
+                    "type": "string"
+                },
+                "unmatched_statement_lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ReconciliationLine"
+                    }
+                },
+                "unmatched_statement_total": {
+                    "type": "string"
+                },
+                "unmatched_transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CashTransaction"
+                    }
+                }
+            }
+        },
+        "domain.StatementImportResult": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "closing_balance": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatementLineResult"
+                    }
+                },
+                "opening_balance": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "unmatched": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.StatementLineResult": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TokenPair": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TwoFactorEnrollment": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "qr_code": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TwoFactorStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "enabled_at": {
+                    "type": "string"
+                },
+                "recovery_codes_left": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.User": {
+            "type": "object",
+            "properties": {
+                "cash_transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CashTransaction"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "description": "EmailVerifiedAt stays nil until the user opens the link sent to\ntheir address; VerificationSentAt throttles resending that link,\nand PasswordResetSentAt sending password reset links.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CashCountItemRequest": {
+            "type": "object",
+            "properties": {
+                "denomination": {
+                    "type": "string",
+                    "example": "100000"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handler.CashCountRequest": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "items"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CashCountItemRequest"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "in",
+                        "out",
+                        "both"
+                    ]
+                }
+            }
+        },
+        "handler.ClosePeriodRequest": {
+            "type": "object",
+            "required": [
+                "period_end",
+                "period_start"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                }
+            }
+        },
+        "handler.CompleteLoginRequest": {
+            "type": "object",
+            "required": [
+                "challenge",
+                "code"
+            ],
+            "properties": {
+                "challenge": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateAccountRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "account_number": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "opening_balance": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "bank",
+                        "ewallet"
+                    ]
                 }
             }
         },
-        "domain.CashTransaction": {
+        "handler.CreateReconciliationRequest": {
             "type": "object",
+            "required": [
+                "account_id",
+                "period_end",
+                "period_start"
+            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "account_id": {
+                    "type": "integer"
                 },
-                "category": {
-                    "description": "Relasi",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CashCategory"
-                        }
-                    ]
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ReconciliationLineRequest"
+                    }
                 },
-                "category_id": {
+                "note": {
+                    "type": "string"
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "statement_balance": {
+                    "type": "string",
+                    "example": "1500000.00"
+                }
+            }
+        },
+        "handler.CreateTransactionRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "account_id": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
+                "amount": {
+                    "type": "string",
+                    "example": "15000.00"
                 },
-                "created_by": {
+                "category_id": {
                     "type": "integer"
                 },
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
                 "payment_method": {
                     "type": "string"
                 },
-                "reference_id": {
-                    "type": "integer"
-                },
                 "transaction_date": {
                     "type": "string"
                 },
                 "type": {
+                    "type": "string",
+                    "enum": [
+                        "in",
+                        "out"
+                    ]
+                }
+            }
+        },
+        "handler.DisableTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "updated_at": {
+                "password": {
                     "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/domain.User"
                 }
             }
         },
-        "domain.User": {
+        "handler.ForgotPasswordRequest": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
-                "cash_transactions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.CashTransaction"
-                    }
-                },
-                "created_at": {
-                    "type": "string"
-                },
                 "email": {
                     "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
@@ -393,6 +4138,77 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "all_sessions": {
+                    "type": "boolean"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MatchLineRequest": {
+            "type": "object",
+            "required": [
+                "transaction_id"
+            ],
+            "properties": {
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.OpeningBalanceRequest": {
+            "type": "object",
+            "required": [
+                "account_id"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "500000.00"
+                }
+            }
+        },
+        "handler.ReconciliationLineRequest": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "-25000.00"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-15"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "handler.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.RegisterRequest": {
             "type": "object",
             "required": [
@@ -412,6 +4228,197 @@ const docTemplate = `{
                     "minLength": 6
                 }
             }
+        },
+        "handler.ReopenPeriodRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TransferRequest": {
+            "type": "object",
+            "required": [
+                "from_account_id",
+                "to_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1000000.00"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                },
+                "transaction_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateAccountRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "account_number": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "bank",
+                        "ewallet"
+                    ]
+                }
+            }
+        },
+        "handler.UpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "cashier",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "handler.UpdateTransactionRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "15000.00"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "in",
+                        "out"
+                    ]
+                }
+            }
+        },
+        "handler.VoidTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,16 +15,24 @@ type CustomClaims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint, email string) (string, error) {
+// GenerateToken issues an access token valid for ttl. Every token gets a
+// random ID (the jti claim) so it can be revoked on its own.
+func GenerateToken(userID uint, email, role string, ttl time.Duration) (string, error) {
 	jwtKey := []byte(viper.GetString("JWT_SECRET"))
-	expirationTime := time.Now().Add(60 * time.Minute)
+	jti, err := RandomToken(16)
+	if err != nil {
+		return "", err
+	}
 
+	now := time.Now()
 	claims := &CustomClaims{
 		UserID: userID,
 		Email:  email,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   email,
 		},
 	}
@@ -36,3 +45,23 @@ func GenerateToken(userID uint, email string) (string, error) {
 
 	return tokenString, nil
 }
+
+// ParseToken verifies an HS256 access token and returns its claims. Tokens
+// without a jti were issued before tokens could be revoked and are refused.
+func ParseToken(tokenString string) (*CustomClaims, error) {
+	jwtKey := []byte(viper.GetString("JWT_SECRET"))
+	claims := &CustomClaims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+var ErrInvalidToken = errors.New("invalid token")
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"go-project/internal/domain"
+)
+
+// Permission names an action a route requires. Roles are granted a fixed
+// set of permissions; a token carries the role, not the permissions.
+type Permission string
+
+const (
+	// PermCashRead covers viewing the book: transactions, balances,
+	// reports and exports.
+	PermCashRead Permission = "cash:read"
+	// PermCashWrite covers the cashier's daily work: recording, editing,
+	// voiding and importing transactions, transfers, cash counts and
+	// reconciliation matching.
+	PermCashWrite Permission = "cash:write"
+	// PermCashManage covers the set-up of the book: accounts, categories,
+	// opening balances, completing reconciliations and closing periods.
+	PermCashManage Permission = "cash:manage"
+	// PermPeriodReopen allows unlocking a closed period.
+	PermPeriodReopen Permission = "period:reopen"
+	PermUserRead     Permission = "users:read"
+	PermUserManage   Permission = "users:manage"
+	// PermSecurityRead allows viewing the security event log.
+	PermSecurityRead Permission = "security:read"
+)
+
+var rolePermissions = map[string][]Permission{
+	domain.RoleOwner: {
+		PermCashRead, PermCashWrite, PermCashManage, PermPeriodReopen, PermUserRead, PermUserManage, PermSecurityRead,
+	},
+	domain.RoleAdmin: {
+		PermCashRead, PermCashWrite, PermCashManage, PermPeriodReopen, PermUserRead, PermUserManage, PermSecurityRead,
+	},
+	domain.RoleCashier: {PermCashRead, PermCashWrite},
+	domain.RoleViewer:  {PermCashRead},
+}
+
+// HasPermission reports whether role is granted permission. Unknown roles,
+// including the empty role of tokens issued before roles existed, have no
+// permissions.
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
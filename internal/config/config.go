@@ -2,6 +2,8 @@ package config
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -18,6 +20,62 @@ type Config struct {
 	GIN_MODE    string
 	TIME_FORMAT string
 	TIME_ZONE   string
+
+	SeedOnStartup bool
+	SeedDir       string
+
+	// AccessTokenTTL and RefreshTokenTTL are Go durations such as "15m"
+	// or "720h".
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	ResetTokenTTL   time.Duration
+
+	// ResetResendInterval is how long to wait before emailing another
+	// password reset link to the same address.
+	ResetResendInterval time.Duration
+
+	// VerifyTokenTTL is how long an email verification link works, and
+	// VerifyResendInterval how long to wait before sending another one.
+	// UnverifiedLogin is deny, limited (read-only access) or allow.
+	VerifyTokenTTL       time.Duration
+	VerifyResendInterval time.Duration
+	UnverifiedLogin      string
+
+	// TwoFactorIssuer is the name authenticator apps show for the account.
+	TwoFactorIssuer       string
+	TwoFactorChallengeTTL time.Duration
+
+	// Failed logins within LoginFailureWindow are counted per account and
+	// per IP; past the free attempts each failure locks logins for
+	// LoginLockoutBase, doubling up to LoginLockoutMax.
+	LoginAccountFreeAttempts int
+	LoginIPFreeAttempts      int
+	LoginFailureWindow       time.Duration
+	LoginLockoutBase         time.Duration
+	LoginLockoutMax          time.Duration
+
+	// Requests that email someone, like a password reset, are accepted
+	// MailIPLimit times per client IP; the count starts again after a
+	// quiet MailIPWindow.
+	MailIPLimit  int
+	MailIPWindow time.Duration
+
+	// TrustedProxies lists the proxies (IPs or CIDRs) whose
+	// X-Forwarded-For header is believed. Empty means none: the client IP
+	// is the address of the connection.
+	TrustedProxies []string
+
+	// AppURL is the base of links sent by email.
+	AppURL string
+
+	// MailDriver is smtp, log or file.
+	MailDriver   string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	MailDir      string
 }
 
 func LoadConfig() Config {
@@ -35,11 +93,38 @@ func LoadConfig() Config {
 	viper.SetDefault("DB_NAME", "mydb")
 
 	viper.SetDefault("JWT_SECRET", "secret_key")
+	viper.SetDefault("ACCESS_TOKEN_TTL", "15m")
+	viper.SetDefault("REFRESH_TOKEN_TTL", "720h")
+	viper.SetDefault("RESET_TOKEN_TTL", "1h")
+	viper.SetDefault("RESET_RESEND_INTERVAL", "1m")
+	viper.SetDefault("VERIFY_TOKEN_TTL", "48h")
+	viper.SetDefault("VERIFY_RESEND_INTERVAL", "1m")
+	viper.SetDefault("UNVERIFIED_LOGIN", "deny")
+	viper.SetDefault("TWO_FACTOR_ISSUER", "Buku Kas")
+	viper.SetDefault("TWO_FACTOR_CHALLENGE_TTL", "5m")
+	viper.SetDefault("LOGIN_ACCOUNT_FREE_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_IP_FREE_ATTEMPTS", 20)
+	viper.SetDefault("LOGIN_FAILURE_WINDOW", "1h")
+	viper.SetDefault("LOGIN_LOCKOUT_BASE", "30s")
+	viper.SetDefault("LOGIN_LOCKOUT_MAX", "15m")
+	viper.SetDefault("MAIL_IP_LIMIT", 10)
+	viper.SetDefault("MAIL_IP_WINDOW", "1h")
+	viper.SetDefault("TRUSTED_PROXIES", "")
 	viper.SetDefault("GIN_MODE", "")
 
 	viper.SetDefault("TIME_FORMAT", "02-01-2006 15:04:05")
 	viper.SetDefault("TIME_ZONE", "Asia/Jakarta")
 
+	viper.SetDefault("SEED_ON_STARTUP", true)
+	viper.SetDefault("SEED_DIR", "seeds")
+
+	viper.SetDefault("APP_URL", "http://localhost:3000")
+	viper.SetDefault("MAIL_DRIVER", "log")
+	viper.SetDefault("MAIL_FROM", "Buku Kas <no-reply@localhost>")
+	viper.SetDefault("SMTP_HOST", "127.0.0.1")
+	viper.SetDefault("SMTP_PORT", 1025)
+	viper.SetDefault("MAIL_DIR", "mails")
+
 	viper.AutomaticEnv()
 
 	return Config{
@@ -53,5 +138,53 @@ func LoadConfig() Config {
 		GIN_MODE:    viper.GetString("GIN_MODE"),
 		TIME_FORMAT: viper.GetString("TIME_FORMAT"),
 		TIME_ZONE:   viper.GetString("TIME_ZONE"),
+
+		SeedOnStartup: viper.GetBool("SEED_ON_STARTUP"),
+		SeedDir:       viper.GetString("SEED_DIR"),
+
+		AccessTokenTTL:  viper.GetDuration("ACCESS_TOKEN_TTL"),
+		RefreshTokenTTL: viper.GetDuration("REFRESH_TOKEN_TTL"),
+		ResetTokenTTL:   viper.GetDuration("RESET_TOKEN_TTL"),
+
+		ResetResendInterval: viper.GetDuration("RESET_RESEND_INTERVAL"),
+
+		VerifyTokenTTL:       viper.GetDuration("VERIFY_TOKEN_TTL"),
+		VerifyResendInterval: viper.GetDuration("VERIFY_RESEND_INTERVAL"),
+		UnverifiedLogin:      viper.GetString("UNVERIFIED_LOGIN"),
+
+		TwoFactorIssuer:       viper.GetString("TWO_FACTOR_ISSUER"),
+		TwoFactorChallengeTTL: viper.GetDuration("TWO_FACTOR_CHALLENGE_TTL"),
+
+		LoginAccountFreeAttempts: viper.GetInt("LOGIN_ACCOUNT_FREE_ATTEMPTS"),
+		LoginIPFreeAttempts:      viper.GetInt("LOGIN_IP_FREE_ATTEMPTS"),
+		LoginFailureWindow:       viper.GetDuration("LOGIN_FAILURE_WINDOW"),
+		LoginLockoutBase:         viper.GetDuration("LOGIN_LOCKOUT_BASE"),
+		LoginLockoutMax:          viper.GetDuration("LOGIN_LOCKOUT_MAX"),
+
+		MailIPLimit:  viper.GetInt("MAIL_IP_LIMIT"),
+		MailIPWindow: viper.GetDuration("MAIL_IP_WINDOW"),
+
+		TrustedProxies: splitList(viper.GetString("TRUSTED_PROXIES")),
+
+		AppURL: viper.GetString("APP_URL"),
+
+		MailDriver:   viper.GetString("MAIL_DRIVER"),
+		MailFrom:     viper.GetString("MAIL_FROM"),
+		SMTPHost:     viper.GetString("SMTP_HOST"),
+		SMTPPort:     viper.GetInt("SMTP_PORT"),
+		SMTPUsername: viper.GetString("SMTP_USERNAME"),
+		SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+		MailDir:      viper.GetString("MAIL_DIR"),
+	}
+}
+
+// splitList reads a comma separated setting, skipping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
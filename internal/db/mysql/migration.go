@@ -7,10 +7,150 @@ import (
 )
 
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	hadRoles := db.Migrator().HasColumn(&domain.User{}, "role")
+	hadVerification := db.Migrator().HasColumn(&domain.User{}, "email_verified_at")
+
+	err := db.AutoMigrate(
 		&domain.User{},
+		&domain.RefreshToken{},
+		&domain.RevokedToken{},
+		&domain.PasswordResetToken{},
+		&domain.TwoFactor{},
+		&domain.RecoveryCode{},
+		&domain.LoginChallenge{},
+		&domain.LoginThrottle{},
+		&domain.SecurityEvent{},
+		&domain.CashAccount{},
 		&domain.CashCategory{},
 		&domain.CashTransaction{},
 		&domain.CashBalance{},
+		&domain.Reconciliation{},
+		&domain.ReconciliationLine{},
+		&domain.CashCount{},
+		&domain.CashCountItem{},
+		&domain.PeriodClose{},
+		&domain.PeriodBalanceSnapshot{},
+		&domain.PeriodCategorySnapshot{},
+		&domain.PeriodEvent{},
 	)
+	if err != nil {
+		return err
+	}
+
+	if err := migrateDefaultAccount(db); err != nil {
+		return err
+	}
+	if err := migrateSystemCategories(db); err != nil {
+		return err
+	}
+	if !hadRoles {
+		if err := migrateUserRoles(db); err != nil {
+			return err
+		}
+	}
+	if !hadVerification {
+		return migrateVerifiedUsers(db)
+	}
+	return nil
+}
+
+// migrateSystemCategories marks the category of each system key. Books that
+// predate the keys were found by name, so an unmarked category with the
+// default name is marked; otherwise the category is created.
+func migrateSystemCategories(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for key, system := range domain.SystemCategories {
+			var count int64
+			err := tx.Model(&domain.CashCategory{}).Where("system_key = ?", key).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+
+			var category domain.CashCategory
+			err = tx.Where("system_key IS NULL AND LOWER(name) = LOWER(?)", system.Name).Order("id asc").First(&category).Error
+			if err == gorm.ErrRecordNotFound {
+				system.SystemKey = &key
+				if err := tx.Create(&system).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&category).Update("system_key", key).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateVerifiedUsers runs once, when email verification is introduced.
+// Users registered before could not verify, so they are taken as verified
+// since they registered rather than locked out.
+func migrateVerifiedUsers(db *gorm.DB) error {
+	return db.Model(&domain.User{}).
+		Where("email_verified_at IS NULL").
+		Update("email_verified_at", gorm.Expr("created_at")).Error
+}
+
+// migrateUserRoles runs once, when roles are introduced. Every user could
+// post before, so existing users become cashiers, and the earliest user
+// becomes the owner who hands out the other roles.
+func migrateUserRoles(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.User{}).Where("1 = 1").Update("role", domain.RoleCashier).Error
+		if err != nil {
+			return err
+		}
+
+		var first domain.User
+		err = tx.Order("id asc").First(&first).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&first).Update("role", domain.RoleOwner).Error
+	})
+}
+
+// migrateDefaultAccount moves books kept before cash accounts existed into a
+// "Kas Tunai" account.
+func migrateDefaultAccount(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var orphans int64
+		err := tx.Model(&domain.CashTransaction{}).Where("account_id IS NULL").Count(&orphans).Error
+		if err != nil {
+			return err
+		}
+		var orphanBalances int64
+		err = tx.Model(&domain.CashBalance{}).Where("account_id = 0").Count(&orphanBalances).Error
+		if err != nil {
+			return err
+		}
+		if orphans == 0 && orphanBalances == 0 {
+			return nil
+		}
+
+		account := domain.CashAccount{Name: "Kas Tunai", Type: "cash"}
+		if err := tx.Create(&account).Error; err != nil {
+			return err
+		}
+
+		err = tx.Model(&domain.CashTransaction{}).
+			Where("account_id IS NULL").
+			Update("account_id", account.ID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&domain.CashBalance{}).
+			Where("account_id = 0").
+			Update("account_id", account.ID).Error
+	})
 }
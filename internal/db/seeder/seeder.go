@@ -0,0 +1,202 @@
+package seeder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go-project/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type CategorySeed struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+type AccountSeed struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Provider      string `json:"provider"`
+	AccountNumber string `json:"account_number"`
+}
+
+// SeedFile is the layout of a per-deployment seed file in the seed
+// directory.
+type SeedFile struct {
+	Accounts   []AccountSeed  `json:"accounts"`
+	Categories []CategorySeed `json:"categories"`
+}
+
+// seedRecord remembers which seed entries were applied, so a category that
+// was renamed or archived afterwards is not created again.
+type seedRecord struct {
+	Key       string    `gorm:"primaryKey;size:191"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (seedRecord) TableName() string {
+	return "seed_records"
+}
+
+var DefaultAccounts = []AccountSeed{
+	{Name: "Kas Tunai", Type: "cash"},
+}
+
+var DefaultCategories = []CategorySeed{
+	{Name: "Penjualan", Type: "in", Description: "Hasil penjualan barang atau produk"},
+	{Name: "Pendapatan Jasa", Type: "in", Description: "Penerimaan dari jasa yang diberikan"},
+	{Name: "Modal Pemilik", Type: "in", Description: "Setoran modal dari pemilik usaha"},
+	{Name: "Pendapatan Lain-lain", Type: "in", Description: "Penerimaan di luar kegiatan usaha utama"},
+	{Name: "Pembelian Barang Dagangan", Type: "out", Description: "Pembelian stok barang untuk dijual kembali"},
+	{Name: "Pembelian Bahan Baku", Type: "out", Description: "Pembelian bahan untuk produksi"},
+	{Name: "Gaji", Type: "out", Description: "Gaji dan upah karyawan"},
+	{Name: "Sewa", Type: "out", Description: "Sewa tempat usaha atau peralatan"},
+	{Name: "Listrik", Type: "out", Description: "Tagihan listrik"},
+	{Name: "Air", Type: "out", Description: "Tagihan air / PDAM"},
+	{Name: "Internet & Telepon", Type: "out", Description: "Pulsa, paket data, internet dan telepon"},
+	{Name: "Transportasi", Type: "out", Description: "Bensin, ongkos kirim dan transportasi"},
+	{Name: "Perlengkapan", Type: "out", Description: "Alat tulis, kemasan dan perlengkapan habis pakai"},
+	{Name: "Perawatan & Perbaikan", Type: "out", Description: "Servis dan perbaikan peralatan atau tempat usaha"},
+	{Name: "Pajak & Retribusi", Type: "out", Description: "Pajak, retribusi dan iuran"},
+	{Name: "Prive Pemilik", Type: "out", Description: "Pengambilan uang untuk keperluan pribadi pemilik"},
+	{Name: "Biaya Lain-lain", Type: "out", Description: "Pengeluaran di luar kategori lain"},
+	{Name: "Pinjaman", Type: "both", Description: "Penerimaan dan pembayaran pinjaman"},
+}
+
+// Run applies the default seed set followed by every *.json file in dir in
+// name order. Entries that were applied before are skipped, so it is safe
+// to call on every start. A missing dir is not an error.
+func Run(db *gorm.DB, dir string) error {
+	if err := db.AutoMigrate(&seedRecord{}); err != nil {
+		return err
+	}
+
+	if err := seedAccounts(db, DefaultAccounts); err != nil {
+		return fmt.Errorf("default seed: %w", err)
+	}
+	if err := seedCategories(db, DefaultCategories); err != nil {
+		return fmt.Errorf("default seed: %w", err)
+	}
+
+	files, err := seedFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		file, err := readSeedFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := seedAccounts(db, file.Accounts); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := seedCategories(db, file.Categories); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func seedFiles(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func readSeedFile(path string) (*SeedFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file SeedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+func seedAccounts(db *gorm.DB, seeds []AccountSeed) error {
+	for _, seed := range seeds {
+		name := strings.TrimSpace(seed.Name)
+		if name == "" {
+			return errors.New("account name is required")
+		}
+		if !domain.IsValidAccountType(seed.Type) {
+			return fmt.Errorf("account %q: invalid type %q", name, seed.Type)
+		}
+
+		account := domain.CashAccount{
+			Name:          name,
+			Type:          seed.Type,
+			Provider:      seed.Provider,
+			AccountNumber: seed.AccountNumber,
+		}
+		if err := applyOnce(db, "account:"+strings.ToLower(name), &account, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seedCategories(db *gorm.DB, seeds []CategorySeed) error {
+	for _, seed := range seeds {
+		name := strings.TrimSpace(seed.Name)
+		if name == "" {
+			return errors.New("category name is required")
+		}
+		if !domain.IsValidCategoryType(seed.Type) {
+			return fmt.Errorf("category %q: invalid type %q", name, seed.Type)
+		}
+
+		category := domain.CashCategory{Name: name, Type: seed.Type, Description: seed.Description}
+		if err := applyOnce(db, "category:"+strings.ToLower(name), &category, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOnce creates record unless the seed key was applied before or a row
+// with the same name (case-insensitive) already exists, then marks the key
+// as applied.
+func applyOnce(db *gorm.DB, key string, record any, name string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		applied, err := isApplied(tx, key)
+		if err != nil || applied {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(record).Where("LOWER(name) = ?", strings.ToLower(name)).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			if err := tx.Create(record).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&seedRecord{Key: key, AppliedAt: time.Now()}).Error
+	})
+}
+
+func isApplied(db *gorm.DB, key string) (bool, error) {
+	var count int64
+	err := db.Model(&seedRecord{}).Where("key = ?", key).Count(&count).Error
+	return count > 0, err
+}
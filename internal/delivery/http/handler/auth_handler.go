@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"go-project/internal/delivery/http/response"
 	"go-project/internal/delivery/http/validator"
@@ -14,6 +17,18 @@ import (
 type AuthHandler interface {
 	Register(c *gin.Context)
 	Login(c *gin.Context)
+	Refresh(c *gin.Context)
+	Logout(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+	ResendVerification(c *gin.Context)
+	CompleteLogin(c *gin.Context)
+	GetTwoFactorStatus(c *gin.Context)
+	EnrollTwoFactor(c *gin.Context)
+	ConfirmTwoFactor(c *gin.Context)
+	RegenerateRecoveryCodes(c *gin.Context)
+	DisableTwoFactor(c *gin.Context)
 }
 
 type authHandler struct {
@@ -35,9 +50,31 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	AllSessions  bool   `json:"all_sessions"`
+}
+
 // Register godoc
 // @Summary Daftarkan pengguna baru
-// @Description Buat akun pengguna baru
+// @Description Buat akun pengguna baru. Akun belum terverifikasi sampai tautan verifikasi yang dikirim ke emailnya dibuka
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -71,19 +108,24 @@ func (h *authHandler) Register(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"id":    createdUser.ID,
-		"name":  createdUser.Name,
-		"email": createdUser.Email,
+		"id":             createdUser.ID,
+		"name":           createdUser.Name,
+		"email":          createdUser.Email,
+		"email_verified": createdUser.IsEmailVerified(),
 	})
 }
 
 // Login godoc
 // @Summary Login user
-// @Description Otentikasi pengguna dan dapatkan token JWT
+// @Description Otentikasi pengguna dan dapatkan access token JWT berumur pendek (token) beserta refresh token. Tergantung konfigurasi, akun yang emailnya belum terverifikasi ditolak atau hanya mendapat akses baca. Bila autentikasi dua faktor aktif, respons berisi two_factor_required dan challenge (lihat domain.TwoFactorChallenge) yang diselesaikan lewat /auth/login/2fa. Terlalu banyak percobaan gagal untuk satu akun atau IP mengunci login sementara, makin lama untuk tiap kegagalan berikutnya
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "Login request"
+// @Success 200 {object} domain.TokenPair
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse "Email not verified"
+// @Failure 429 {object} response.ErrorResponse "Too many failed attempts"
 // @Router /auth/login [post]
 func (h *authHandler) Login(c *gin.Context) {
 	var req LoginRequest
@@ -96,15 +138,288 @@ func (h *authHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.uc.Login(req.Email, req.Password)
+	tokens, challenge, err := h.uc.Login(req.Email, req.Password, clientInfo(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+		loginError(c, err)
+		return
+	}
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh godoc
+// @Summary Perbarui token
+// @Description Tukar refresh token dengan pasangan token baru; refresh token lama tidak berlaku lagi. Refresh token yang dipakai ulang dianggap bocor dan seluruh sesi login tersebut dicabut
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "Refresh request"
+// @Success 200 {object} domain.TokenPair
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse "Email not verified"
+// @Router /auth/refresh [post]
+func (h *authHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Success: false,
-			Message: "Invalid email or password",
+			Message: "Failed to refresh token",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	tokens, err := h.uc.RefreshToken(req.RefreshToken)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			status = http.StatusForbidden
+		} else if !errors.Is(err, usecase.ErrInvalidRefreshToken) && !errors.Is(err, usecase.ErrRefreshTokenReused) {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to refresh token",
+			Errors:  map[string]string{"refresh_token": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Cabut access token yang dipakai dan refresh token sesi ini. Dengan all_sessions, semua sesi pengguna dicabut, termasuk access token sesi lain
+// @Tags auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body LogoutRequest false "Logout request"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /auth/logout [post]
+func (h *authHandler) Logout(c *gin.Context) {
+	var req LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{
+				Success: false,
+				Message: "Failed to logout",
+				Errors:  validator.PesanError(err),
+			})
+			return
+		}
+	}
+
+	session := domain.Session{
+		UserID:    c.GetUint("user_id"),
+		JTI:       c.GetString("jti"),
+		ExpiresAt: c.GetTime("token_expires_at"),
+	}
+	if err := h.uc.Logout(session, req.RefreshToken, req.AllSessions); err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to logout",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true, Message: "Logged out"})
+}
+
+// ForgotPassword godoc
+// @Summary Lupa kata sandi
+// @Description Kirim tautan atur ulang kata sandi ke email pengguna. Respons selalu sama, baik alamat tidak terdaftar, permintaan yang terlalu rapat untuk alamat yang sama atau terlalu banyak dari satu IP (yang diabaikan tanpa mengirim email), maupun email yang gagal terkirim
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Forgot password request"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Router /auth/forgot-password [post]
+func (h *authHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to request password reset",
 			Errors:  validator.PesanError(err),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	if err := h.uc.ForgotPassword(req.Email, clientInfo(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to request password reset",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Success: true,
+		Message: "If the email is registered, a password reset link has been sent",
+	})
+}
+
+// ResetPassword godoc
+// @Summary Atur ulang kata sandi
+// @Description Tetapkan kata sandi baru dengan token dari email lupa kata sandi. Token hanya berlaku sekali, dan semua sesi lain harus login ulang
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset password request"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Router /auth/reset-password [post]
+func (h *authHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to reset password",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	if err := h.uc.ResetPassword(req.Token, req.Password); err != nil {
+		status := http.StatusBadRequest
+		if !errors.Is(err, usecase.ErrInvalidResetToken) {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to reset password",
+			Errors:  map[string]string{"token": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true, Message: "Password has been reset"})
+}
+
+// VerifyEmail godoc
+// @Summary Verifikasi email
+// @Description Tandai alamat email terverifikasi dengan token dari tautan di email verifikasi. Membuka tautan yang sama lagi tidak menghasilkan galat
+// @Tags auth
+// @Produce json
+// @Param token query string true "Token verifikasi"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Router /auth/verify-email [get]
+func (h *authHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to verify email",
+			Errors:  map[string]string{"token": "token is required"},
+		})
+		return
+	}
+
+	if err := h.uc.VerifyEmail(token); err != nil {
+		status := http.StatusBadRequest
+		if !errors.Is(err, usecase.ErrInvalidVerificationToken) {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to verify email",
+			Errors:  map[string]string{"token": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true, Message: "Email has been verified"})
+}
+
+// ResendVerification godoc
+// @Summary Kirim ulang email verifikasi
+// @Description Kirim tautan verifikasi baru ke alamat yang belum terverifikasi. Respons selalu sama, baik alamat tidak terdaftar, sudah terverifikasi, permintaan yang terlalu rapat atau terlalu banyak dari satu IP (yang diabaikan tanpa mengirim email), maupun email yang gagal terkirim
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResendVerificationRequest true "Resend verification request"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Router /auth/resend-verification [post]
+func (h *authHandler) ResendVerification(c *gin.Context) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to resend verification email",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	if err := h.uc.ResendVerification(req.Email, clientInfo(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to resend verification email",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Success: true,
+		Message: "If the email is registered and not yet verified, a verification link has been sent",
+	})
+}
+
+// clientInfo identifies the client of a login request for throttling and
+// the security event log. ClientIP only follows X-Forwarded-For when the
+// connection comes from a configured trusted proxy.
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// loginError answers a failed login. Unknown emails and wrong passwords
+// get the same response, and a lockout says when to try again.
+func loginError(c *gin.Context, err error) {
+	var locked *usecase.LoginLockedError
+	switch {
+	case errors.As(err, &locked):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, response.ErrorResponse{
+			Success: false,
+			Message: "Too many failed login attempts",
+			Errors:  map[string]string{"credentials": err.Error()},
+		})
+	case errors.Is(err, usecase.ErrEmailNotVerified):
+		c.JSON(http.StatusForbidden, response.ErrorResponse{
+			Success: false,
+			Message: "Email address has not been verified",
+			Errors:  map[string]string{"email": err.Error()},
+		})
+	case errors.Is(err, usecase.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+			Success: false,
+			Message: "Invalid email or password",
+			Errors:  map[string]string{"credentials": err.Error()},
+		})
+	case errors.Is(err, usecase.ErrInvalidChallenge), errors.Is(err, usecase.ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to login user",
+			Errors:  map[string]string{"code": err.Error()},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to login user",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+	}
 }
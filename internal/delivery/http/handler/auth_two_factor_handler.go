@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go-project/internal/delivery/http/response"
+	"go-project/internal/delivery/http/validator"
+	"go-project/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompleteLoginRequest struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+func twoFactorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidTwoFactorCode),
+		errors.Is(err, usecase.ErrInvalidPassword):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrTwoFactorEnabled),
+		errors.Is(err, usecase.ErrTwoFactorNotEnabled),
+		errors.Is(err, usecase.ErrTwoFactorNotEnrolled):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// CompleteLogin godoc
+// @Summary Selesaikan login dua faktor
+// @Description Tukar challenge dari /auth/login dengan pasangan token, memakai kode TOTP dari aplikasi autentikator atau kode pemulihan yang belum dipakai. Challenge berumur pendek dan hangus setelah beberapa kode salah
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body CompleteLoginRequest true "Complete login request"
+// @Success 200 {object} domain.TokenPair
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse "Too many failed attempts"
+// @Router /auth/login/2fa [post]
+func (h *authHandler) CompleteLogin(c *gin.Context) {
+	var req CompleteLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to login user",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	tokens, err := h.uc.CompleteLogin(req.Challenge, req.Code, clientInfo(c))
+	if err != nil {
+		loginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// GetTwoFactorStatus godoc
+// @Summary Status autentikasi dua faktor
+// @Description Ambil apakah autentikasi dua faktor aktif dan sisa kode pemulihan
+// @Tags 2fa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} domain.TwoFactorStatus
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/2fa [get]
+func (h *authHandler) GetTwoFactorStatus(c *gin.Context) {
+	status, err := h.uc.GetTwoFactorStatus(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to get two-factor status",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// EnrollTwoFactor godoc
+// @Summary Daftarkan autentikasi dua faktor
+// @Description Buat secret TOTP baru beserta URI otpauth dan kode QR (PNG data URI) untuk dipindai aplikasi autentikator. Belum aktif sampai dikonfirmasi lewat /api/2fa/confirm
+// @Tags 2fa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} domain.TwoFactorEnrollment
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse
+// @Router /api/2fa/enroll [post]
+func (h *authHandler) EnrollTwoFactor(c *gin.Context) {
+	enrollment, err := h.uc.EnrollTwoFactor(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), response.ErrorResponse{
+			Success: false,
+			Message: "Failed to enroll two-factor authentication",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Aktifkan autentikasi dua faktor
+// @Description Aktifkan autentikasi dua faktor dengan kode TOTP dari aplikasi autentikator. Respons berisi kode pemulihan yang hanya ditampilkan sekali ini
+// @Tags 2fa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "Kode TOTP"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse
+// @Router /api/2fa/confirm [post]
+func (h *authHandler) ConfirmTwoFactor(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to enable two-factor authentication",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	codes, err := h.uc.ConfirmTwoFactor(c.GetUint("user_id"), req.Code)
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), response.ErrorResponse{
+			Success: false,
+			Message: "Failed to enable two-factor authentication",
+			Errors:  map[string]string{"code": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Success: true,
+		Message: "Two-factor authentication enabled; store the recovery codes somewhere safe",
+		Data:    gin.H{"recovery_codes": codes},
+	})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Buat ulang kode pemulihan
+// @Description Ganti semua kode pemulihan dengan yang baru setelah memeriksa kode TOTP. Kode lama tidak berlaku lagi
+// @Tags 2fa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "Kode TOTP"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse
+// @Router /api/2fa/recovery-codes [post]
+func (h *authHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to regenerate recovery codes",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	codes, err := h.uc.RegenerateRecoveryCodes(c.GetUint("user_id"), req.Code)
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), response.ErrorResponse{
+			Success: false,
+			Message: "Failed to regenerate recovery codes",
+			Errors:  map[string]string{"code": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Success: true,
+		Message: "Recovery codes regenerated",
+		Data:    gin.H{"recovery_codes": codes},
+	})
+}
+
+// DisableTwoFactor godoc
+// @Summary Nonaktifkan autentikasi dua faktor
+// @Description Matikan autentikasi dua faktor dengan kata sandi dan kode TOTP atau kode pemulihan
+// @Tags 2fa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body DisableTwoFactorRequest true "Disable two-factor request"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse
+// @Router /api/2fa/disable [post]
+func (h *authHandler) DisableTwoFactor(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Success: false,
+			Message: "Failed to disable two-factor authentication",
+			Errors:  validator.PesanError(err),
+		})
+		return
+	}
+
+	if err := h.uc.DisableTwoFactor(c.GetUint("user_id"), req.Password, req.Code); err != nil {
+		c.JSON(twoFactorErrorStatus(err), response.ErrorResponse{
+			Success: false,
+			Message: "Failed to disable two-factor authentication",
+			Errors:  map[string]string{"error": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true, Message: "Two-factor authentication disabled"})
+}
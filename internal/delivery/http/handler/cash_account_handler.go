@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"go-project/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateAccountRequest struct {
+	Name           string       `json:"name" binding:"required,max=100"`
+	Type           string       `json:"type" binding:"required,oneof=cash bank ewallet"`
+	Provider       string       `json:"provider" binding:"max=50"`
+	AccountNumber  string       `json:"account_number" binding:"max=50"`
+	OpeningBalance domain.Money `json:"opening_balance" swaggertype:"string" example:"0.00"`
+}
+
+type UpdateAccountRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	Type          string `json:"type" binding:"required,oneof=cash bank ewallet"`
+	Provider      string `json:"provider" binding:"max=50"`
+	AccountNumber string `json:"account_number" binding:"max=50"`
+}
+
+// GetAccounts godoc
+// @Summary Ambil daftar akun kas
+// @Description Menampilkan akun tempat uang disimpan: kas laci (cash), rekening bank (bank) atau dompet digital (ewallet)
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param include_archived query bool false "Sertakan akun yang diarsipkan"
+// @Success 200 {object} map[string]interface{} "List akun kas"
+// @Failure 500 {object} map[string]interface{} "Server Error"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/accounts [get]
+func (h *CashHandler) GetAccounts(c *gin.Context) {
+	includeArchived, _ := strconv.ParseBool(c.Query("include_archived"))
+
+	accounts, err := h.uc.GetAccounts(includeArchived)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"accounts": accounts})
+}
+
+// CreateAccount godoc
+// @Summary Tambah akun kas
+// @Description Tambah akun kas baru beserta saldo awalnya
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param account body CreateAccountRequest true "Data akun kas"
+// @Success 201 {object} map[string]interface{} "Akun kas yang dibuat"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/accounts [post]
+func (h *CashHandler) CreateAccount(c *gin.Context) {
+	var req CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.uc.CreateAccount(domain.CashAccount{
+		Name:           req.Name,
+		Type:           req.Type,
+		Provider:       req.Provider,
+		AccountNumber:  req.AccountNumber,
+		OpeningBalance: req.OpeningBalance,
+	})
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"account": account})
+}
+
+// UpdateAccount godoc
+// @Summary Ubah akun kas
+// @Description Ubah nama, jenis, penyedia atau nomor akun kas. Saldo awal diubah lewat /api/cash/opening-balance
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID akun kas"
+// @Param account body UpdateAccountRequest true "Data akun kas"
+// @Success 200 {object} map[string]interface{} "Akun kas yang diubah"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/accounts/{id} [put]
+func (h *CashHandler) UpdateAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.uc.UpdateAccount(uint(id), domain.CashAccount{
+		Name:          req.Name,
+		Type:          req.Type,
+		Provider:      req.Provider,
+		AccountNumber: req.AccountNumber,
+	})
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"account": account})
+}
+
+// ArchiveAccount godoc
+// @Summary Arsipkan akun kas
+// @Description Arsipkan akun kas agar tidak dapat menerima transaksi baru; riwayat dan saldonya tetap tersimpan
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID akun kas"
+// @Success 200 {object} map[string]interface{} "Akun kas yang diarsipkan"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/accounts/{id}/archive [post]
+func (h *CashHandler) ArchiveAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	account, err := h.uc.ArchiveAccount(uint(id))
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"account": account})
+}
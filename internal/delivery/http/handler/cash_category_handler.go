@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"go-project/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Type        string `json:"type" binding:"required,oneof=in out both"`
+	Description string `json:"description"`
+}
+
+// GetCategories godoc
+// @Summary Ambil daftar kategori kas
+// @Description Menampilkan semua kategori transaksi kas (uang masuk / keluar)
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param include_archived query bool false "Sertakan kategori yang diarsipkan"
+// @Success 200 {object} map[string]interface{} "List kategori kas"
+// @Failure 500 {object} map[string]interface{} "Server Error"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/categories [get]
+func (h *CashHandler) GetCategories(c *gin.Context) {
+	includeArchived, _ := strconv.ParseBool(c.Query("include_archived"))
+
+	cats, err := h.uc.GetCategories(includeArchived)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": cats})
+}
+
+// CreateCategory godoc
+// @Summary Tambah kategori kas
+// @Description Tambah kategori untuk uang masuk (in), uang keluar (out) atau keduanya (both)
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param category body CategoryRequest true "Data kategori"
+// @Success 201 {object} map[string]interface{} "Kategori yang dibuat"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/categories [post]
+func (h *CashHandler) CreateCategory(c *gin.Context) {
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.uc.CreateCategory(domain.CashCategory{
+		Name:        req.Name,
+		Type:        req.Type,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"category": category})
+}
+
+// UpdateCategory godoc
+// @Summary Ubah kategori kas
+// @Description Ubah nama, jenis atau keterangan kategori. Jenis tidak dapat dipersempit bila masih ada transaksi jenis lain
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID kategori"
+// @Param category body CategoryRequest true "Data kategori"
+// @Success 200 {object} map[string]interface{} "Kategori yang diubah"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/categories/{id} [put]
+func (h *CashHandler) UpdateCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.uc.UpdateCategory(uint(id), domain.CashCategory{
+		Name:        req.Name,
+		Type:        req.Type,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"category": category})
+}
+
+// ArchiveCategory godoc
+// @Summary Arsipkan kategori kas
+// @Description Arsipkan kategori agar tidak dapat dipakai transaksi baru; transaksi lama tetap memakai kategori ini. Kategori sistem tidak dapat diarsipkan
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID kategori"
+// @Success 200 {object} map[string]interface{} "Kategori yang diarsipkan"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/categories/{id}/archive [post]
+func (h *CashHandler) ArchiveCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	category, err := h.uc.ArchiveCategory(uint(id))
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"category": category})
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"go-project/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CashCountRequest struct {
+	AccountID uint                   `json:"account_id" binding:"required"`
+	Date      string                 `json:"date" example:"2024-01-31"`
+	Note      string                 `json:"note"`
+	Items     []CashCountItemRequest `json:"items" binding:"required"`
+}
+
+type CashCountItemRequest struct {
+	Denomination domain.Money `json:"denomination" swaggertype:"string" example:"100000"`
+	Quantity     int          `json:"quantity" example:"12"`
+}
+
+// GetCashCounts godoc
+// @Summary Daftar hitung kas fisik
+// @Description Daftar hitung kas fisik, terbaru lebih dulu, dapat difilter per akun dan rentang tanggal
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param account_id query int false "ID akun kas"
+// @Param start query string false "Tanggal awal (YYYY-MM-DD)"
+// @Param end query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Success 200 {object} map[string]interface{} "Daftar hitung kas"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 500 {object} map[string]interface{} "Server Error"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/counts [get]
+func (h *CashHandler) GetCashCounts(c *gin.Context) {
+	accountID, start, end, err := h.accountPeriodQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	counts, err := h.uc.GetCashCounts(accountID, start, end)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"counts": counts})
+}
+
+// CountCash godoc
+// @Summary Catat hitung kas fisik
+// @Description Catat jumlah lembar dan keping uang di laci akun kas tunai per pecahan rupiah (100000, 50000, 20000, 10000, 5000, 2000, 1000, 500, 200, 100) pada akhir hari. Total hitungan dibandingkan dengan saldo akhir buku hari itu; selisihnya baru dibukukan setelah hitungan dikonfirmasi. Tanggal kosong berarti hari ini
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param count body CashCountRequest true "Hitungan per pecahan"
+// @Success 201 {object} map[string]interface{} "Hitung kas berstatus draft"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/counts [post]
+func (h *CashHandler) CountCash(c *gin.Context) {
+	var req CashCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := h.parseDate("date", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := domain.CashCount{
+		AccountID: req.AccountID,
+		Date:      date,
+		Note:      req.Note,
+		CountedBy: c.GetUint("user_id"),
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, domain.CashCountItem{
+			Denomination: item.Denomination,
+			Quantity:     item.Quantity,
+		})
+	}
+
+	count, err := h.uc.CountCash(input)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"count": count})
+}
+
+// GetCashCount godoc
+// @Summary Detail hitung kas fisik
+// @Description Detail hitung kas beserta rincian pecahan dan transaksi selisih bila sudah dikonfirmasi
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID hitung kas"
+// @Success 200 {object} map[string]interface{} "Hitung kas"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/counts/{id} [get]
+func (h *CashHandler) GetCashCount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid count id"})
+		return
+	}
+
+	count, err := h.uc.GetCashCount(uint(id))
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// ConfirmCashCount godoc
+// @Summary Konfirmasi hitung kas dan tutup hari
+// @Description Konfirmasi hitung kas sebagai penutupan hari akun tersebut. Selisih dihitung ulang terhadap buku saat ini; kelebihan kas dicatat sebagai transaksi masuk dan kekurangan sebagai transaksi keluar berkategori "Selisih Kas" pada akhir hari yang dihitung, sehingga saldo akhir buku sama dengan hitungan. Satu akun hanya dapat ditutup sekali per hari
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID hitung kas"
+// @Success 200 {object} map[string]interface{} "Hitung kas yang dikonfirmasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/counts/{id}/confirm [post]
+func (h *CashHandler) ConfirmCashCount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid count id"})
+		return
+	}
+
+	count, err := h.uc.ConfirmCashCount(uint(id), c.GetUint("user_id"))
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
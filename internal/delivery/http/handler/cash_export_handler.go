@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/export"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportTransactions godoc
+// @Summary Ekspor transaksi kas ke CSV
+// @Description Unduh seluruh transaksi yang cocok dengan filter sebagai CSV tanpa paginasi. Baris dikirim bertahap langsung dari database sehingga ekspor data setahun tidak dimuat sekaligus ke memori
+// @Tags Cash
+// @Security BearerAuth
+// @Produce text/csv
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
+// @Param start query string false "Tanggal mulai (YYYY-MM-DD)"
+// @Param end query string false "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD)"
+// @Param type query string false "Jenis transaksi" Enums(in, out)
+// @Param kind query string false "Jenis pencatatan" Enums(regular, transfer)
+// @Param category_id query int false "ID kategori"
+// @Param payment_method query string false "Metode pembayaran"
+// @Param created_by query int false "ID pengguna pembuat"
+// @Param min_amount query string false "Nominal minimum"
+// @Param max_amount query string false "Nominal maksimum"
+// @Param q query string false "Cari di keterangan"
+// @Param sort query string false "Urutan" Enums(date_asc, date_desc, amount_asc, amount_desc)
+// @Param delimiter query string false "Pemisah kolom, bawaan comma" Enums(comma, semicolon, tab, pipe)
+// @Param number_format query string false "Format angka: plain (1234567.50) atau id (1.234.567,50)" Enums(plain, id)
+// @Success 200 {file} file "File CSV"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transactions/export [get]
+func (h *CashHandler) ExportTransactions(c *gin.Context) {
+	filter, err := h.transactionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	options, err := h.csvOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The response is only started once the first row arrives, so errors
+	// raised while validating the filter still get a JSON body.
+	var out *export.TransactionCSV
+	start := func() (err error) {
+		h.attachment(c, "text/csv; charset=utf-8", "transaksi", "csv")
+		out, err = export.NewTransactionCSV(c.Writer, options)
+		return err
+	}
+
+	err = h.uc.ExportTransactions(filter, func(transaction domain.CashTransaction) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return out.Write(transaction)
+	})
+	if err == nil && out == nil {
+		err = start()
+	}
+	if err != nil {
+		if out == nil {
+			h.cashError(c, err)
+			return
+		}
+		// The status line has already been sent; cut the download short.
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if err := out.Flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// ExportSummaryReport godoc
+// @Summary Ekspor laporan ringkasan kas ke CSV
+// @Description Unduh laporan ringkasan per periode sebagai CSV: saldo awal, satu baris per periode (didahului rincian kategori jika by_category) dan baris total dengan saldo akhir
+// @Tags Cash
+// @Security BearerAuth
+// @Produce text/csv
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
+// @Param start query string false "Tanggal mulai (YYYY-MM-DD), bawaan awal bulan ini"
+// @Param end query string false "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD), bawaan hari ini"
+// @Param group_by query string false "Periode pengelompokan" Enums(day, week, month, year)
+// @Param by_category query bool false "Sertakan rincian kategori per periode"
+// @Param delimiter query string false "Pemisah kolom, bawaan comma" Enums(comma, semicolon, tab, pipe)
+// @Param number_format query string false "Format angka: plain (1234567.50) atau id (1.234.567,50)" Enums(plain, id)
+// @Success 200 {file} file "File CSV"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reports/summary/export [get]
+func (h *CashHandler) ExportSummaryReport(c *gin.Context) {
+	options, err := h.csvOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.summaryReport(c)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	h.attachment(c, "text/csv; charset=utf-8", "laporan-ringkasan", "csv")
+	if err := export.WriteSummaryCSV(c.Writer, report, options); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// csvOptions reads the delimiter and number_format export options.
+func (h *CashHandler) csvOptions(c *gin.Context) (export.CSVOptions, error) {
+	delimiter, err := export.ParseDelimiter(c.Query("delimiter"))
+	if err != nil {
+		return export.CSVOptions{}, err
+	}
+	numberFormat, err := export.ParseNumberFormat(c.Query("number_format"))
+	if err != nil {
+		return export.CSVOptions{}, err
+	}
+	return export.CSVOptions{
+		Delimiter:    delimiter,
+		NumberFormat: numberFormat,
+		Location:     h.location,
+	}, nil
+}
+
+// attachment starts a 200 download response named after today's date in
+// the book's time zone.
+func (h *CashHandler) attachment(c *gin.Context, contentType, name, extension string) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().In(h.location).Format("20060102"), extension)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+}
+
+// cashBookDocument is a cash book file that is fed the transactions of the
+// book in date order.
+type cashBookDocument interface {
+	Write(domain.CashTransaction) error
+	Finish() error
+	WriteTo(io.Writer) (int64, error)
+}
+
+// ExportCashBook godoc
+// @Summary Ekspor buku kas ke Excel atau PDF
+// @Description Unduh buku kas untuk satu periode. xlsx: lembar Ringkasan per bulan dan satu lembar per bulan berisi saldo awal, transaksi dengan saldo berjalan dan total berupa rumus. pdf: "Laporan Buku Kas" siap cetak dengan saldo awal, transaksi dan saldo berjalan, rekap per kategori, saldo akhir serta kolom tanda tangan Bendahara dan Ketua
+// @Tags Cash
+// @Security BearerAuth
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
+// @Param start query string false "Tanggal mulai (YYYY-MM-DD), bawaan awal bulan ini"
+// @Param end query string false "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD), bawaan hari ini"
+// @Param format query string false "Format file, bawaan xlsx" Enums(xlsx, pdf)
+// @Param bendahara query string false "Nama Bendahara untuk kolom tanda tangan (pdf)"
+// @Param ketua query string false "Nama Ketua untuk kolom tanda tangan (pdf)"
+// @Success 200 {file} file "File buku kas"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reports/cash-book/export [get]
+func (h *CashHandler) ExportCashBook(c *gin.Context) {
+	format := c.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("format %q tidak didukung", format)})
+		return
+	}
+
+	accountID, start, end, err := h.accountPeriodQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.uc.GetCashBook(accountID, start, end)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	var document cashBookDocument
+	var contentType, name string
+	switch format {
+	case "pdf":
+		document = export.NewCashBookPDF(book, h.location, export.SignatureNames{
+			Treasurer: c.Query("bendahara"),
+			Chairman:  c.Query("ketua"),
+		})
+		contentType, name = "application/pdf", "laporan-buku-kas"
+	default:
+		workbook, err := export.NewCashBookXLSX(book, h.location)
+		if err != nil {
+			h.cashError(c, err)
+			return
+		}
+		defer workbook.Close()
+		document = workbook
+		contentType, name = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "buku-kas"
+	}
+
+	filter := domain.TransactionFilter{AccountID: book.AccountID, Start: book.Start, End: book.End}
+	if err := h.uc.ExportTransactions(filter, document.Write); err != nil {
+		h.cashError(c, err)
+		return
+	}
+	if err := document.Finish(); err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	h.attachment(c, contentType, name, format)
+	if _, err := document.WriteTo(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
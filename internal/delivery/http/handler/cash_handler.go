@@ -1,117 +1,548 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"go-project/internal/domain"
+	"go-project/internal/export"
+	"go-project/internal/importer"
 	"go-project/internal/usecase"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 type CashHandler struct {
-	uc usecase.CashUsecase
+	uc       usecase.CashUsecase
+	location *time.Location
+	logger   *zap.Logger
 }
 
-func NewCashHandler(uc usecase.CashUsecase) *CashHandler {
-	return &CashHandler{uc: uc}
+func NewCashHandler(uc usecase.CashUsecase, location *time.Location, logger *zap.Logger) *CashHandler {
+	if location == nil {
+		location = time.Local
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &CashHandler{uc: uc, location: location, logger: logger}
 }
 
 // CreateTransaction godoc
 // @Summary Tambah transaksi kas
-// @Description Tambah transaksi uang masuk atau keluar (requires JWT token)
+// @Description Tambah transaksi uang masuk atau keluar pada akun kas tertentu (requires JWT token). transaction_date boleh diisi tanggal lampau; jika kosong dipakai waktu sekarang
 // @Tags Cash
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param transaction body domain.CashTransaction true "Data transaksi kas"
+// @Param transaction body CreateTransactionRequest true "Data transaksi kas"
 // @Success 201 {object} map[string]interface{} "Transaction recorded successfully"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
-// @Router /cash-transactions [post]
+// @Failure 500 {object} map[string]interface{} "Server Error"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transactions [post]
 func (h *CashHandler) CreateTransaction(c *gin.Context) {
-	var transaction domain.CashTransaction
-	if err := c.ShouldBindJSON(&transaction); err != nil {
+	var req CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.uc.RecordTransaction(transaction); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	err := h.uc.RecordTransaction(domain.CashTransaction{
+		TransactionDate: req.TransactionDate,
+		AccountID:       req.AccountID,
+		Type:            req.Type,
+		CategoryID:      req.CategoryID,
+		Description:     req.Description,
+		Amount:          req.Amount,
+		PaymentMethod:   req.PaymentMethod,
+		CreatedBy:       c.GetUint("user_id"),
+	})
+	if err != nil {
+		h.cashError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Transaction recorded successfully"})
 }
 
+// CreateTransactionRequest holds the fields a client may set on a new
+// transaction; everything else is filled in by the server.
+type CreateTransactionRequest struct {
+	TransactionDate time.Time    `json:"transaction_date"`
+	AccountID       uint         `json:"account_id"`
+	Type            string       `json:"type" binding:"required,oneof=in out"`
+	CategoryID      uint         `json:"category_id"`
+	Description     string       `json:"description"`
+	Amount          domain.Money `json:"amount" swaggertype:"string" example:"15000.00"`
+	PaymentMethod   string       `json:"payment_method"`
+}
+
+type UpdateTransactionRequest struct {
+	TransactionDate time.Time    `json:"transaction_date"`
+	AccountID       uint         `json:"account_id"`
+	Type            string       `json:"type" binding:"required,oneof=in out"`
+	CategoryID      uint         `json:"category_id"`
+	Description     string       `json:"description"`
+	Amount          domain.Money `json:"amount" swaggertype:"string" example:"15000.00"`
+	PaymentMethod   string       `json:"payment_method"`
+}
+
+type VoidTransactionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// UpdateTransaction godoc
+// @Summary Ubah transaksi kas
+// @Description Ubah nominal, jenis, kategori atau keterangan transaksi dan hitung ulang saldo hari itu serta hari-hari berikutnya
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID transaksi"
+// @Param transaction body UpdateTransactionRequest true "Data transaksi kas"
+// @Success 200 {object} map[string]interface{} "Transaksi yang sudah diubah"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transactions/{id} [put]
+func (h *CashHandler) UpdateTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	var req UpdateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.uc.UpdateTransaction(uint(id), domain.CashTransaction{
+		TransactionDate: req.TransactionDate,
+		AccountID:       req.AccountID,
+		Type:            req.Type,
+		CategoryID:      req.CategoryID,
+		Description:     req.Description,
+		Amount:          req.Amount,
+		PaymentMethod:   req.PaymentMethod,
+	})
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transaction": transaction})
+}
+
+// VoidTransaction godoc
+// @Summary Batalkan transaksi kas
+// @Description Batalkan transaksi dengan membuat transaksi pembalik pada tanggal yang sama; transaksi asli tetap tersimpan
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID transaksi"
+// @Param request body VoidTransactionRequest false "Alasan pembatalan"
+// @Success 200 {object} map[string]interface{} "Transaksi pembalik"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transactions/{id}/void [post]
+func (h *CashHandler) VoidTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	var req VoidTransactionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	reversal, err := h.uc.VoidTransaction(uint(id), c.GetUint("user_id"), req.Reason)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reversal": reversal})
+}
+
 // GetTransactions godoc
 // @Summary Ambil daftar transaksi kas
-// @Description Ambil daftar transaksi kas berdasarkan rentang tanggal
+// @Description Ambil daftar transaksi kas per halaman (cursor) dengan filter. Ringkasan total masuk/keluar dihitung dari seluruh hasil filter; transfer antar akun tidak dihitung kecuali kind=transfer
 // @Tags Cash
 // @Security BearerAuth
 // @Produce json
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
 // @Param start query string false "Tanggal mulai (YYYY-MM-DD)"
-// @Param end query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Param end query string false "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD)"
+// @Param type query string false "Jenis transaksi" Enums(in, out)
+// @Param kind query string false "Jenis pencatatan" Enums(regular, transfer)
+// @Param category_id query int false "ID kategori"
+// @Param payment_method query string false "Metode pembayaran"
+// @Param created_by query int false "ID pengguna pembuat"
+// @Param min_amount query string false "Nominal minimum"
+// @Param max_amount query string false "Nominal maksimum"
+// @Param q query string false "Cari di keterangan"
+// @Param sort query string false "Urutan" Enums(date_asc, date_desc, amount_asc, amount_desc)
+// @Param limit query int false "Jumlah per halaman (maks. 200)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya, dengan urutan (sort) yang sama"
 // @Success 200 {object} map[string]interface{} "List transaksi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 500 {object} map[string]interface{} "Server Error"
-// @Router /cash-transactions [get]
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transactions [get]
 func (h *CashHandler) GetTransactions(c *gin.Context) {
-	startStr := c.Query("start")
-	endStr := c.Query("end")
-
-	start, _ := time.Parse("2006-01-02", startStr)
-	end, _ := time.Parse("2006-01-02", endStr)
-	if end.IsZero() {
-		end = time.Now()
+	filter, err := h.transactionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
+	limit, err := queryUint(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Limit = int(limit)
 
-	data, err := h.uc.GetReport(start, end)
+	page, err := h.uc.ListTransactions(filter, c.Query("cursor"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.cashError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"transactions": data})
+	c.JSON(http.StatusOK, gin.H{
+		"transactions": page.Transactions,
+		"meta": gin.H{
+			"next_cursor": page.NextCursor,
+			"has_more":    page.HasMore,
+			"limit":       page.Limit,
+			"summary":     page.Summary,
+		},
+	})
+}
+
+// transactionFilter reads the listing filters shared by the transaction
+// endpoints from the query string.
+func (h *CashHandler) transactionFilter(c *gin.Context) (domain.TransactionFilter, error) {
+	filter := domain.TransactionFilter{
+		Type:          c.Query("type"),
+		Kind:          c.Query("kind"),
+		PaymentMethod: c.Query("payment_method"),
+		Search:        strings.TrimSpace(c.Query("q")),
+		Sort:          c.Query("sort"),
+	}
+
+	var err error
+	if filter.AccountID, filter.Start, filter.End, err = h.accountPeriodQuery(c); err != nil {
+		return filter, err
+	}
+	if filter.CategoryID, err = queryUint(c, "category_id"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBy, err = queryUint(c, "created_by"); err != nil {
+		return filter, err
+	}
+
+	if value := c.Query("min_amount"); value != "" {
+		amount, err := domain.ParseMoney(value)
+		if err != nil {
+			return filter, fmt.Errorf("min_amount: %w", err)
+		}
+		filter.MinAmount = &amount
+	}
+	if value := c.Query("max_amount"); value != "" {
+		amount, err := domain.ParseMoney(value)
+		if err != nil {
+			return filter, fmt.Errorf("max_amount: %w", err)
+		}
+		filter.MaxAmount = &amount
+	}
+	return filter, nil
 }
 
 // GetBalance godoc
 // @Summary Lihat saldo kas harian
-// @Description Menghitung dan menampilkan saldo kas untuk tanggal tertentu
+// @Description Menghitung dan menampilkan saldo kas untuk tanggal tertentu, per akun atau gabungan semua akun termasuk yang diarsipkan
 // @Tags Cash
 // @Security BearerAuth
 // @Produce json
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
 // @Param date query string false "Tanggal (YYYY-MM-DD)"
 // @Success 200 {object} map[string]interface{} "Data saldo kas harian"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
 // @Failure 500 {object} map[string]interface{} "Server Error"
-// @Router /cash-balance [get]
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/balance [get]
 func (h *CashHandler) GetBalance(c *gin.Context) {
-	dateStr := c.Query("date")
-	date, _ := time.Parse("2006-01-02", dateStr)
+	accountID, err := queryUint(c, "account_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := h.parseDate("date", c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if date.IsZero() {
 		date = time.Now()
 	}
 
-	balance, err := h.uc.CalculateDailyBalance(date)
+	balance, err := h.uc.CalculateDailyBalance(accountID, date)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.cashError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"balance": balance})
 }
 
-// GetCategories godoc
-// @Summary Ambil daftar kategori kas
-// @Description Menampilkan semua kategori transaksi kas (uang masuk / keluar)
+type OpeningBalanceRequest struct {
+	AccountID uint         `json:"account_id" binding:"required"`
+	Amount    domain.Money `json:"amount" swaggertype:"string" example:"500000.00"`
+}
+
+// SetOpeningBalance godoc
+// @Summary Atur saldo awal akun kas
+// @Description Atur saldo awal akun kas; saldo awal dan akhir setiap hari yang tersimpan pada akun tersebut ikut disesuaikan
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body OpeningBalanceRequest true "Saldo awal"
+// @Success 200 {object} map[string]interface{} "Data akun kas"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/opening-balance [put]
+func (h *CashHandler) SetOpeningBalance(c *gin.Context) {
+	var req OpeningBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.uc.SetOpeningBalance(req.AccountID, req.Amount)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"account": account})
+}
+
+// RecalculateBalances godoc
+// @Summary Hitung ulang saldo kas
+// @Description Hitung ulang saldo harian dari transaksi mulai tanggal tertentu hingga hari terakhir. Periode yang sudah ditutup dilewati tanpa diubah; hari sesudahnya dilanjutkan dari saldo akhir periode tersebut
 // @Tags Cash
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} map[string]interface{} "List kategori kas"
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD)"
+// @Success 200 {object} map[string]interface{} "Saldo berhasil dihitung ulang"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
 // @Failure 500 {object} map[string]interface{} "Server Error"
-// @Router /cash-categories [get]
-func (h *CashHandler) GetCategories(c *gin.Context) {
-	cats, err := h.uc.GetCategories()
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/balance/recalculate [post]
+func (h *CashHandler) RecalculateBalances(c *gin.Context) {
+	accountID, err := queryUint(c, "account_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	from, err := h.parseDate("from", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.uc.RecalculateBalances(accountID, from); err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Balances recalculated successfully"})
+}
+
+// cashNotFoundErrors are answered with 404.
+var cashNotFoundErrors = []error{
+	usecase.ErrTransactionNotFound,
+	usecase.ErrCategoryNotFound,
+	usecase.ErrAccountNotFound,
+	usecase.ErrReconciliationNotFound,
+	usecase.ErrReconciliationLineNotFound,
+	usecase.ErrCashCountNotFound,
+	usecase.ErrPeriodNotFound,
+}
+
+// cashClientErrors are requests the book refuses; they are answered with
+// 400 and their message.
+var cashClientErrors = []error{
+	domain.ErrInvalidMoney,
+	domain.ErrMoneyOverflow,
+	domain.ErrMoneyScale,
+	domain.ErrInvalidCursor,
+	domain.ErrCursorSortMismatch,
+	export.ErrInvalidDelimiter,
+	export.ErrInvalidNumberFormat,
+	importer.ErrEmptyFile,
+	importer.ErrInvalidDateFormat,
+	importer.ErrMappingAmountRequired,
+	importer.ErrMappingCategoryRequired,
+	importer.ErrMappingDateRequired,
+	importer.ErrTooManyRows,
+	importer.ErrEmptyStatement,
+	importer.ErrUnknownStatementFormat,
+	usecase.ErrAccountArchived,
+	usecase.ErrAccountNameRequired,
+	usecase.ErrInvalidAccount,
+	usecase.ErrInvalidAccountType,
+	usecase.ErrCategoryNameRequired,
+	usecase.ErrCategoryTypeInUse,
+	usecase.ErrInvalidCategoryType,
+	usecase.ErrSystemCategory,
+	usecase.ErrSystemCategoryType,
+	usecase.ErrSystemCategoryArchive,
+	usecase.ErrCashCountConfirmed,
+	usecase.ErrCashCountDayClosed,
+	usecase.ErrCashCountItemsRequired,
+	usecase.ErrCashCountNotCashAccount,
+	usecase.ErrDuplicateDenomination,
+	usecase.ErrFutureCountDate,
+	usecase.ErrInvalidDenomination,
+	usecase.ErrInvalidQuantity,
+	usecase.ErrImportAccountRequired,
+	usecase.ErrInvalidAmountRange,
+	usecase.ErrInvalidSort,
+	usecase.ErrInvalidTransactionKind,
+	usecase.ErrFuturePeriod,
+	usecase.ErrOpeningBalanceLocked,
+	usecase.ErrPeriodClosed,
+	usecase.ErrPeriodNotClosed,
+	usecase.ErrPeriodOverlap,
+	usecase.ErrPeriodRequired,
+	usecase.ErrReopenReasonRequired,
+	usecase.ErrInvalidMatchTolerance,
+	usecase.ErrReconciliationCompleted,
+	usecase.ErrReconciliationPeriodRequired,
+	usecase.ErrReconciliationUnbalanced,
+	usecase.ErrStatementLineOutOfPeriod,
+	usecase.ErrTransactionAlreadyMatched,
+	usecase.ErrTransactionNotMatchable,
+	usecase.ErrTransactionReconciled,
+	usecase.ErrInvalidDateRange,
+	usecase.ErrInvalidGroupBy,
+	usecase.ErrReportTooLong,
+	usecase.ErrTransferSameAccount,
+	usecase.ErrCategoryArchived,
+	usecase.ErrCategoryTypeMismatch,
+	usecase.ErrFutureTransactionDate,
+	usecase.ErrInvalidAmount,
+	usecase.ErrInvalidCategory,
+	usecase.ErrInvalidTransactionType,
+	usecase.ErrNegativeOpeningBalance,
+	usecase.ErrTransactionIsReversal,
+	usecase.ErrTransactionVoided,
+	usecase.ErrTransferNotEditable,
+}
+
+func cashErrorStatus(err error) int {
+	for _, target := range cashNotFoundErrors {
+		if errors.Is(err, target) {
+			return http.StatusNotFound
+		}
+	}
+	var param *paramError
+	if errors.As(err, &param) {
+		return http.StatusBadRequest
+	}
+	for _, target := range cashClientErrors {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// cashError answers a failed request. Known errors are shown as they are;
+// anything else is logged and answered with a generic message, so database
+// and other internal details do not reach the client.
+func (h *CashHandler) cashError(c *gin.Context, err error) {
+	status := cashErrorStatus(err)
+	if status != http.StatusInternalServerError {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	h.logger.Error("cash request failed",
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+		zap.Error(err),
+	)
+	c.JSON(status, gin.H{"error": "terjadi kesalahan pada server"})
+}
+
+// paramError is a request value that is present but cannot be parsed.
+type paramError struct {
+	key    string
+	reason string
+}
+
+func (e *paramError) Error() string {
+	return e.key + ": " + e.reason
+}
+
+// parseDate reads a YYYY-MM-DD value as midnight in the book's time zone.
+// An empty value is the zero time; a malformed one is an error naming key.
+func (h *CashHandler) parseDate(key, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, h.location)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return time.Time{}, &paramError{key, "format tanggal harus YYYY-MM-DD"}
+	}
+	return date, nil
+}
+
+// parseUint reads an optional numeric id. An empty value is 0; a malformed
+// one is an error naming key.
+func parseUint(key, value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, strconv.IntSize)
+	if err != nil {
+		return 0, &paramError{key, "harus berupa bilangan bulat positif"}
+	}
+	return uint(id), nil
+}
+
+func queryUint(c *gin.Context, key string) (uint, error) {
+	return parseUint(key, c.Query(key))
+}
+
+// accountPeriodQuery reads the account_id, start and end query values that
+// listings and reports are filtered by.
+func (h *CashHandler) accountPeriodQuery(c *gin.Context) (accountID uint, start, end time.Time, err error) {
+	if accountID, err = queryUint(c, "account_id"); err != nil {
+		return
+	}
+	if start, err = h.parseDate("start", c.Query("start")); err != nil {
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"categories": cats})
+	end, err = h.parseDate("end", c.Query("end"))
+	return
 }
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-project/internal/db/mysql"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"go-project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB opens the Postgres database named by TEST_DATABASE_DSN, migrated
+// like the server's, and skips the test when it is not set. Each test
+// books into accounts of its own, so the database may be reused.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mysql.AutoMigrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// Parallel transactions on the same account, some backdated behind days
+// already booked, must all land in the daily totals, and every day must
+// still open with the closing balance of the day before.
+func TestCreateTransactionConcurrent(t *testing.T) {
+	db := testDB(t)
+	gin.SetMode(gin.TestMode)
+
+	uc := usecase.NewCashUsecase(repository.NewCashRepository(db), repository.NewUnitOfWork(db), time.UTC)
+	account, err := uc.CreateAccount(domain.CashAccount{Name: "Uji paralel", Type: "cash", OpeningBalance: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	category, err := uc.CreateCategory(domain.CashCategory{Name: "Uji paralel", Type: "both"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewCashHandler(uc, time.UTC, nil)
+	r := gin.New()
+	r.POST("/api/cash/transactions", func(c *gin.Context) { c.Set("user_id", uint(1)) }, h.CreateTransaction)
+
+	const requests = 60
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	type day struct{ in, out domain.Money }
+	want := make(map[string]*day)
+	bodies := make([][]byte, requests)
+	for i := range bodies {
+		date := today.AddDate(0, 0, -(i % 4)).Add(time.Duration(i) * time.Minute)
+		req := CreateTransactionRequest{
+			TransactionDate: date,
+			AccountID:       account.ID,
+			Type:            "in",
+			CategoryID:      category.ID,
+			Amount:          domain.Money(1000 + i),
+		}
+		key := date.Format("2006-01-02")
+		if want[key] == nil {
+			want[key] = &day{}
+		}
+		if i%3 == 0 {
+			req.Type = "out"
+			want[key].out += req.Amount
+		} else {
+			want[key].in += req.Amount
+		}
+		if bodies[i], err = json.Marshal(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	statuses := make([]int, requests)
+	for i, body := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/cash/transactions", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			statuses[i] = w.Code
+		}()
+	}
+	wg.Wait()
+	for i, status := range statuses {
+		if status != http.StatusCreated {
+			t.Fatalf("request %d: status %d, want %d", i, status, http.StatusCreated)
+		}
+	}
+
+	var count int64
+	if err := db.Model(&domain.CashTransaction{}).Where("account_id = ?", account.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != requests {
+		t.Errorf("%d transactions stored, want %d", count, requests)
+	}
+
+	var balances []domain.CashBalance
+	if err := db.Where("account_id = ?", account.ID).Order("date").Find(&balances).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != len(want) {
+		t.Fatalf("%d daily balances, want %d", len(balances), len(want))
+	}
+	opening := account.OpeningBalance
+	for _, balance := range balances {
+		key := balance.Date.Format("2006-01-02")
+		w := want[key]
+		if w == nil {
+			t.Fatalf("balance for unexpected day %s", key)
+		}
+		got := fmt.Sprintf("opening %s in %s out %s closing %s",
+			balance.OpeningBalance, balance.TotalIn, balance.TotalOut, balance.ClosingBalance)
+		expected := fmt.Sprintf("opening %s in %s out %s closing %s",
+			opening, w.in, w.out, opening+w.in-w.out)
+		if got != expected {
+			t.Errorf("%s: %s, want %s", key, got, expected)
+		}
+		opening = balance.ClosingBalance
+	}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/export"
+	"go-project/internal/importer"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportFileSize = 5 << 20
+
+// ImportTransactions godoc
+// @Summary Impor transaksi kas dari CSV
+// @Description Impor banyak transaksi sekaligus dari file CSV berheader. mapping berisi JSON yang memetakan field transaksi ke nama kolom, mis. {"date":"Tanggal","type":"Jenis","amount":"Nominal","category":"Kategori","description":"Keterangan"}. Tanpa kolom jenis, nominal negatif dianggap keluar; atau petakan kolom in dan out terpisah. Kategori dan akun boleh berupa nama atau ID. Setiap baris divalidasi dan dilaporkan; dengan dry_run tidak ada yang disimpan, tanpa dry_run semua baris yang valid disimpan dalam satu transaksi database dan saldo dihitung ulang sekali
+// @Tags Cash
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File CSV"
+// @Param mapping formData string true "Pemetaan kolom (JSON)"
+// @Param account_id formData int false "ID akun kas untuk baris tanpa kolom akun"
+// @Param dry_run formData bool false "Hanya validasi, tanpa menyimpan"
+// @Param delimiter formData string false "Pemisah kolom, bawaan comma" Enums(comma, semicolon, tab, pipe)
+// @Param number_format formData string false "Format angka: plain (1234567.50) atau id (1.234.567,50)" Enums(plain, id)
+// @Param date_format formData string false "Format tanggal, bawaan YYYY-MM-DD" Enums(YYYY-MM-DD, DD/MM/YYYY, DD-MM-YYYY, MM/DD/YYYY)
+// @Success 200 {object} domain.ImportResult "Hasil validasi (dry run)"
+// @Success 201 {object} domain.ImportResult "Hasil impor"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transactions/import [post]
+func (h *CashHandler) ImportTransactions(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file CSV wajib diunggah"})
+		return
+	}
+	if header.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ukuran file maksimal %d MB", maxImportFileSize>>20)})
+		return
+	}
+
+	var mapping domain.ImportMapping
+	if err := json.Unmarshal([]byte(c.PostForm("mapping")), &mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mapping: " + err.Error()})
+		return
+	}
+	options, err := h.importOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	rows, err := importer.ParseCSV(file, mapping, options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := formUint(c, "account_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	result, err := h.uc.ImportTransactions(rows, accountID, c.GetUint("user_id"), dryRun)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Imported > 0 {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+// importOptions reads the delimiter, number_format and date_format form
+// values of an import.
+func (h *CashHandler) importOptions(c *gin.Context) (importer.CSVOptions, error) {
+	delimiter, err := export.ParseDelimiter(c.PostForm("delimiter"))
+	if err != nil {
+		return importer.CSVOptions{}, err
+	}
+	numberFormat, err := export.ParseNumberFormat(c.PostForm("number_format"))
+	if err != nil {
+		return importer.CSVOptions{}, err
+	}
+	dateLayout, err := importer.ParseDateFormat(c.PostForm("date_format"))
+	if err != nil {
+		return importer.CSVOptions{}, err
+	}
+	return importer.CSVOptions{
+		Delimiter:    delimiter,
+		NumberFormat: numberFormat,
+		DateLayout:   dateLayout,
+		Location:     h.location,
+	}, nil
+}
+
+// ImportStatement godoc
+// @Summary Impor mutasi rekening bank
+// @Description Impor file mutasi bank (OFX, SWIFT MT940 atau ISO 20022 CAMT.053) menjadi transaksi pada akun kas yang dipilih. Baris yang referensi banknya sudah pernah diimpor dilewati (skipped); baris tanpa kategori untuk arahnya, bernominal nol atau bertanggal di masa depan dilaporkan sebagai unmatched
+// @Tags Cash
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File mutasi bank"
+// @Param account_id formData int true "ID akun kas tujuan"
+// @Param format formData string false "Format file; kosong untuk deteksi otomatis" Enums(ofx, mt940, camt053)
+// @Param in_category_id formData int false "ID kategori untuk dana masuk"
+// @Param out_category_id formData int false "ID kategori untuk dana keluar"
+// @Param dry_run formData bool false "Hanya pratinjau, tanpa menyimpan"
+// @Success 200 {object} domain.StatementImportResult "Hasil pratinjau atau impor tanpa transaksi baru"
+// @Success 201 {object} domain.StatementImportResult "Hasil impor"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/statements/import [post]
+func (h *CashHandler) ImportStatement(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file mutasi bank wajib diunggah"})
+		return
+	}
+	if header.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ukuran file maksimal %d MB", maxImportFileSize>>20)})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	statement, err := importer.ParseStatement(file, c.PostForm("format"), h.location)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := domain.StatementImport{CreatedBy: c.GetUint("user_id")}
+	input.DryRun, _ = strconv.ParseBool(c.PostForm("dry_run"))
+	for key, target := range map[string]*uint{
+		"account_id":      &input.AccountID,
+		"in_category_id":  &input.InCategoryID,
+		"out_category_id": &input.OutCategoryID,
+	} {
+		if *target, err = formUint(c, key); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.uc.ImportStatement(statement, input)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.DryRun && result.Created > 0 {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+func formUint(c *gin.Context, key string) (uint, error) {
+	return parseUint(key, c.PostForm(key))
+}
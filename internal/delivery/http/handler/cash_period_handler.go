@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClosePeriodRequest struct {
+	PeriodStart string `json:"period_start" binding:"required" example:"2024-01-01"`
+	PeriodEnd   string `json:"period_end" binding:"required" example:"2024-01-31"`
+	Note        string `json:"note"`
+}
+
+type ReopenPeriodRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// GetPeriodCloses godoc
+// @Summary Daftar periode tutup buku
+// @Description Daftar periode yang pernah ditutup, terbaru lebih dulu, termasuk yang sudah dibuka kembali
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Daftar periode"
+// @Failure 500 {object} map[string]interface{} "Server Error"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/periods [get]
+func (h *CashHandler) GetPeriodCloses(c *gin.Context) {
+	periods, err := h.uc.GetPeriodCloses()
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"periods": periods})
+}
+
+// ClosePeriod godoc
+// @Summary Tutup buku periode
+// @Description Kunci rentang tanggal pada semua akun sehingga transaksi di dalamnya tidak dapat ditambah, diubah atau dibatalkan, dan simpan potret saldo awal dan akhir setiap akun serta total per kategori periode tersebut. Periode tidak boleh bertumpang tindih dengan periode lain yang masih ditutup
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param period body ClosePeriodRequest true "Periode yang ditutup"
+// @Success 201 {object} map[string]interface{} "Periode yang ditutup beserta potretnya"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/periods/close [post]
+func (h *CashHandler) ClosePeriod(c *gin.Context) {
+	var req ClosePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, err := h.parseDate("period_start", req.PeriodStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	end, err := h.parseDate("period_end", req.PeriodEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.uc.ClosePeriod(start, end, c.GetUint("user_id"), req.Note)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"period": period})
+}
+
+// GetPeriodClose godoc
+// @Summary Detail periode tutup buku
+// @Description Periode beserta potret saldo per akun, total per kategori dan riwayat tutup dan buka kembali
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID periode"
+// @Success 200 {object} map[string]interface{} "Periode"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/periods/{id} [get]
+func (h *CashHandler) GetPeriodClose(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period id"})
+		return
+	}
+
+	period, err := h.uc.GetPeriodClose(uint(id))
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"period": period})
+}
+
+// ReopenPeriod godoc
+// @Summary Buka kembali periode tutup buku
+// @Description Buka kunci periode yang sudah ditutup; hanya untuk owner dan admin. Alasan wajib diisi dan dicatat bersama penggunanya. Potret saldo periode tetap tersimpan
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID periode"
+// @Param reopen body ReopenPeriodRequest true "Alasan membuka kembali"
+// @Success 200 {object} map[string]interface{} "Periode yang dibuka kembali"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /api/cash/periods/{id}/reopen [post]
+func (h *CashHandler) ReopenPeriod(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period id"})
+		return
+	}
+
+	var req ReopenPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.uc.ReopenPeriod(uint(id), c.GetUint("user_id"), req.Reason)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"period": period})
+}
@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/importer"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateReconciliationRequest struct {
+	AccountID        uint                        `json:"account_id" binding:"required"`
+	PeriodStart      string                      `json:"period_start" binding:"required" example:"2024-01-01"`
+	PeriodEnd        string                      `json:"period_end" binding:"required" example:"2024-01-31"`
+	StatementBalance domain.Money                `json:"statement_balance" swaggertype:"string" example:"1500000.00"`
+	Note             string                      `json:"note"`
+	Lines            []ReconciliationLineRequest `json:"lines" binding:"dive"`
+}
+
+type ReconciliationLineRequest struct {
+	Date        string       `json:"date" binding:"required" example:"2024-01-15"`
+	Amount      domain.Money `json:"amount" swaggertype:"string" example:"-25000.00"`
+	Reference   string       `json:"reference" binding:"max=100"`
+	Description string       `json:"description"`
+}
+
+type MatchLineRequest struct {
+	TransactionID uint `json:"transaction_id" binding:"required"`
+}
+
+// GetReconciliations godoc
+// @Summary Daftar rekonsiliasi bank
+// @Description Daftar rekonsiliasi, terbaru lebih dulu, dapat difilter per akun kas
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param account_id query int false "ID akun kas"
+// @Success 200 {object} map[string]interface{} "Daftar rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 500 {object} map[string]interface{} "Server Error"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations [get]
+func (h *CashHandler) GetReconciliations(c *gin.Context) {
+	accountID, err := queryUint(c, "account_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reconciliations, err := h.uc.GetReconciliations(accountID)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reconciliations": reconciliations})
+}
+
+// CreateReconciliation godoc
+// @Summary Buat rekonsiliasi bank
+// @Description Buka rekonsiliasi akun kas untuk satu periode dengan saldo akhir menurut rekening koran. Baris mutasi boleh dikirim langsung (nominal bertanda: positif masuk, negatif keluar) atau dimuat kemudian dari file mutasi
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param reconciliation body CreateReconciliationRequest true "Data rekonsiliasi"
+// @Success 201 {object} map[string]interface{} "Rekonsiliasi yang dibuat"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations [post]
+func (h *CashHandler) CreateReconciliation(c *gin.Context) {
+	var req CreateReconciliationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := domain.Reconciliation{
+		AccountID:        req.AccountID,
+		StatementBalance: req.StatementBalance,
+		Note:             req.Note,
+		CreatedBy:        c.GetUint("user_id"),
+	}
+	var err error
+	if input.PeriodStart, err = h.parseDate("period_start", req.PeriodStart); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.PeriodEnd, err = h.parseDate("period_end", req.PeriodEnd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for i, line := range req.Lines {
+		date, err := h.parseDate(fmt.Sprintf("lines[%d].date", i), line.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		input.Lines = append(input.Lines, domain.ReconciliationLine{
+			Date:        date,
+			Amount:      line.Amount,
+			Reference:   line.Reference,
+			Description: line.Description,
+		})
+	}
+
+	reconciliation, err := h.uc.CreateReconciliation(input)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"reconciliation": reconciliation})
+}
+
+// GetReconciliation godoc
+// @Summary Laporan rekonsiliasi bank
+// @Description Saldo buku dan rekening koran pada akhir periode beserta penjelasan selisihnya: pasangan yang cocok, baris mutasi yang belum tercatat di buku, transaksi yang belum muncul di rekening koran, dan selisih yang belum terjelaskan
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID rekonsiliasi"
+// @Success 200 {object} domain.ReconciliationReport "Laporan rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations/{id} [get]
+func (h *CashHandler) GetReconciliation(c *gin.Context) {
+	id, ok := reconciliationID(c)
+	if !ok {
+		return
+	}
+	report, err := h.uc.GetReconciliationReport(id)
+	h.reconciliationResponse(c, report, err)
+}
+
+// LoadReconciliationStatement godoc
+// @Summary Muat mutasi bank ke rekonsiliasi
+// @Description Muat baris file mutasi bank (OFX, SWIFT MT940 atau ISO 20022 CAMT.053) yang bertanggal dalam periode rekonsiliasi. Baris yang referensinya sudah dimuat dilewati; saldo akhir pada file, bila ada, menggantikan saldo rekening koran
+// @Tags Cash
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "ID rekonsiliasi"
+// @Param file formData file true "File mutasi bank"
+// @Param format formData string false "Format file; kosong untuk deteksi otomatis" Enums(ofx, mt940, camt053)
+// @Success 200 {object} domain.ReconciliationReport "Laporan rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations/{id}/statement [post]
+func (h *CashHandler) LoadReconciliationStatement(c *gin.Context) {
+	id, ok := reconciliationID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file mutasi bank wajib diunggah"})
+		return
+	}
+	if header.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ukuran file maksimal %d MB", maxImportFileSize>>20)})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	statement, err := importer.ParseStatement(file, c.PostForm("format"), h.location)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.uc.LoadStatement(id, statement)
+	h.reconciliationResponse(c, report, err)
+}
+
+// MatchReconciliation godoc
+// @Summary Cocokkan mutasi bank otomatis
+// @Description Cocokkan baris mutasi yang belum cocok dengan transaksi akun: pertama menurut referensi bank, lalu nominal sama pada tanggal yang sama, lalu pencocokan fuzzy menurut kedekatan nominal, tanggal dan keterangan dalam batas toleransi
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID rekonsiliasi"
+// @Param date_tolerance query int false "Toleransi selisih tanggal dalam hari (1-31), bawaan 3"
+// @Param amount_tolerance query string false "Toleransi selisih nominal, bawaan 0"
+// @Success 200 {object} domain.ReconciliationReport "Laporan rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations/{id}/match [post]
+func (h *CashHandler) MatchReconciliation(c *gin.Context) {
+	id, ok := reconciliationID(c)
+	if !ok {
+		return
+	}
+
+	var options domain.MatchOptions
+	if value := c.Query("date_tolerance"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date_tolerance"})
+			return
+		}
+		options.DateTolerance = days
+	}
+	if value := c.Query("amount_tolerance"); value != "" {
+		amount, err := domain.ParseMoney(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid amount_tolerance"})
+			return
+		}
+		options.AmountTolerance = amount
+	}
+
+	report, err := h.uc.MatchReconciliation(id, options)
+	h.reconciliationResponse(c, report, err)
+}
+
+// MatchReconciliationLine godoc
+// @Summary Cocokkan baris mutasi secara manual
+// @Description Pasangkan satu baris mutasi dengan transaksi akun yang sama, menggantikan pasangan sebelumnya
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID rekonsiliasi"
+// @Param line_id path int true "ID baris mutasi"
+// @Param match body MatchLineRequest true "Transaksi pasangan"
+// @Success 200 {object} domain.ReconciliationReport "Laporan rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations/{id}/lines/{line_id}/match [post]
+func (h *CashHandler) MatchReconciliationLine(c *gin.Context) {
+	id, ok := reconciliationID(c)
+	if !ok {
+		return
+	}
+	lineID, err := strconv.ParseUint(c.Param("line_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid line id"})
+		return
+	}
+
+	var req MatchLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.uc.MatchReconciliationLine(id, uint(lineID), req.TransactionID)
+	h.reconciliationResponse(c, report, err)
+}
+
+// UnmatchReconciliationLine godoc
+// @Summary Lepas pasangan baris mutasi
+// @Description Lepaskan transaksi yang dipasangkan dengan satu baris mutasi
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID rekonsiliasi"
+// @Param line_id path int true "ID baris mutasi"
+// @Success 200 {object} domain.ReconciliationReport "Laporan rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations/{id}/lines/{line_id}/match [delete]
+func (h *CashHandler) UnmatchReconciliationLine(c *gin.Context) {
+	id, ok := reconciliationID(c)
+	if !ok {
+		return
+	}
+	lineID, err := strconv.ParseUint(c.Param("line_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid line id"})
+		return
+	}
+
+	report, err := h.uc.UnmatchReconciliationLine(id, uint(lineID))
+	h.reconciliationResponse(c, report, err)
+}
+
+// CompleteReconciliation godoc
+// @Summary Selesaikan rekonsiliasi bank
+// @Description Tutup rekonsiliasi yang selisihnya sudah terjelaskan seluruhnya. Transaksi yang cocok ditandai sudah direkonsiliasi dan tidak dapat diubah atau dibatalkan lagi
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID rekonsiliasi"
+// @Success 200 {object} domain.ReconciliationReport "Laporan rekonsiliasi"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reconciliations/{id}/complete [post]
+func (h *CashHandler) CompleteReconciliation(c *gin.Context) {
+	id, ok := reconciliationID(c)
+	if !ok {
+		return
+	}
+	report, err := h.uc.CompleteReconciliation(id, c.GetUint("user_id"))
+	h.reconciliationResponse(c, report, err)
+}
+
+func reconciliationID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reconciliation id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// reconciliationResponse writes the report returned by a reconciliation
+// usecase call, or its error.
+func (h *CashHandler) reconciliationResponse(c *gin.Context, report *domain.ReconciliationReport, err error) {
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"go-project/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSummaryReport godoc
+// @Summary Laporan ringkasan kas per periode
+// @Description Total masuk, keluar, selisih dan saldo berjalan per hari/minggu/bulan/tahun, dengan rincian per kategori. Transfer antar akun tidak dihitung sebagai pemasukan atau pengeluaran. Paling banyak 366 periode per laporan
+// @Tags Cash
+// @Security BearerAuth
+// @Produce json
+// @Param account_id query int false "ID akun kas; kosong untuk semua akun"
+// @Param start query string false "Tanggal mulai (YYYY-MM-DD), bawaan awal bulan ini"
+// @Param end query string false "Tanggal akhir, termasuk seluruh hari tersebut (YYYY-MM-DD), bawaan hari ini"
+// @Param group_by query string false "Periode pengelompokan" Enums(day, week, month, year)
+// @Param by_category query bool false "Sertakan rincian kategori per periode"
+// @Success 200 {object} map[string]interface{} "Laporan ringkasan"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/reports/summary [get]
+func (h *CashHandler) GetSummaryReport(c *gin.Context) {
+	report, err := h.summaryReport(c)
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+// summaryReport builds the summary report described by the query string
+// shared by the report endpoints.
+func (h *CashHandler) summaryReport(c *gin.Context) (*domain.SummaryReport, error) {
+	byCategory, _ := strconv.ParseBool(c.Query("by_category"))
+	accountID, start, end, err := h.accountPeriodQuery(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.uc.GetSummaryReport(accountID, start, end, c.Query("group_by"), byCategory)
+}
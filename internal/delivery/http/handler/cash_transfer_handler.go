@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"go-project/internal/domain"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferRequest struct {
+	FromAccountID   uint         `json:"from_account_id" binding:"required"`
+	ToAccountID     uint         `json:"to_account_id" binding:"required"`
+	Amount          domain.Money `json:"amount" swaggertype:"string" example:"1000000.00"`
+	TransactionDate time.Time    `json:"transaction_date"`
+	Description     string       `json:"description"`
+}
+
+// CreateTransfer godoc
+// @Summary Transfer antar akun kas
+// @Description Pindahkan dana antar akun kas (mis. setor kas laci ke bank). Dicatat sebagai pasangan transaksi keluar/masuk yang saling terhubung dan tidak dihitung sebagai pemasukan atau pengeluaran. Membatalkan salah satu transaksi akan membatalkan keduanya
+// @Tags Cash
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param transfer body TransferRequest true "Data transfer"
+// @Success 201 {object} map[string]interface{} "Transfer yang dicatat"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/cash/transfers [post]
+func (h *CashHandler) CreateTransfer(c *gin.Context) {
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := h.uc.TransferFunds(domain.CashTransfer{
+		FromAccountID:   req.FromAccountID,
+		ToAccountID:     req.ToAccountID,
+		Amount:          req.Amount,
+		TransactionDate: req.TransactionDate,
+		Description:     req.Description,
+		CreatedBy:       c.GetUint("user_id"),
+	})
+	if err != nil {
+		h.cashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"transfer": transfer})
+}
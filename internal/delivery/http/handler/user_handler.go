@@ -1,12 +1,22 @@
 package handler
 
 import (
+	"errors"
+	"go-project/internal/domain"
 	"go-project/internal/usecase"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin cashier viewer"`
+}
+
 type UserHandler struct {
 	uc usecase.UserUsecase
 }
@@ -45,6 +55,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 // @Tags users
 // @Security BearerAuth
 // @Produce json
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Router /api/get-users [get]
 func (h *UserHandler) GetUsers(c *gin.Context) {
 	users, err := h.uc.GetUsers()
@@ -54,3 +65,104 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"users": users})
 }
+
+// UpdateUserRole godoc
+// @Summary Ubah role user
+// @Description Ubah role pengguna menjadi owner, admin, cashier atau viewer. Hanya owner yang dapat memberi atau mencabut role owner, dan owner terakhir tidak dapat diturunkan. Semua sesi pengguna tersebut dicabut, sehingga role baru berlaku sejak login berikutnya
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID user"
+// @Param request body UpdateRoleRequest true "Role baru"
+// @Success 200 {object} map[string]interface{} "User yang diubah"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /api/users/{id}/role [put]
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.uc.UpdateUserRole(c.GetUint("user_id"), uint(id), req.Role)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, usecase.ErrOwnerRoleRequired):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// GetSecurityEvents godoc
+// @Summary Log keamanan
+// @Description Ambil log keamanan login (berhasil, gagal, diblokir, terkunci, gagal dua faktor), terbaru lebih dulu. Untuk halaman berikutnya kirim next_before_id sebagai before_id
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param type query string false "Jenis event" Enums(login_success, login_failure, login_blocked, login_locked, two_factor_failure)
+// @Param user_id query int false "ID user"
+// @Param email query string false "Email yang dicoba"
+// @Param ip query string false "IP klien"
+// @Param since query string false "Sejak waktu (RFC3339)"
+// @Param until query string false "Sampai sebelum waktu (RFC3339)"
+// @Param before_id query int false "Hanya event dengan ID lebih kecil"
+// @Param limit query int false "Jumlah event (default 50, maksimal 200)"
+// @Success 200 {object} map[string]interface{} "Daftar event"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Router /api/security-events [get]
+func (h *UserHandler) GetSecurityEvents(c *gin.Context) {
+	filter := domain.SecurityEventFilter{
+		Type:  c.Query("type"),
+		Email: strings.TrimSpace(c.Query("email")),
+		IP:    c.Query("ip"),
+	}
+	var limit uint
+	for key, target := range map[string]*uint{"user_id": &filter.UserID, "before_id": &filter.BeforeID, "limit": &limit} {
+		value, err := strconv.ParseUint(c.Query(key), 10, strconv.IntSize)
+		if err != nil && c.Query(key) != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": key + " must be a positive integer"})
+			return
+		}
+		*target = uint(value)
+	}
+	filter.Limit = int(limit)
+	for key, target := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if value := c.Query(key); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": key + " must be an RFC3339 time"})
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	events, err := h.uc.GetSecurityEvents(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	meta := gin.H{"count": len(events)}
+	if len(events) > 0 {
+		meta["next_before_id"] = events[len(events)-1].ID
+	}
+	c.JSON(http.StatusOK, gin.H{"events": events, "meta": meta})
+}
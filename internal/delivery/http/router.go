@@ -1,9 +1,13 @@
 package http
 
 import (
+	"time"
+
+	"go-project/internal/auth"
 	"go-project/internal/config"
 	"go-project/internal/delivery/http/handler"
 	"go-project/internal/delivery/middleware"
+	"go-project/internal/mailer"
 	"go-project/internal/repository"
 	"go-project/internal/usecase"
 
@@ -15,11 +19,12 @@ import (
 
 type Dependencies struct {
 	Logger      *zap.Logger
+	Location    *time.Location
 	UserUsecase usecase.UserUsecase
 	CashUsecase usecase.CashUsecase
 }
 
-func initDeps(db *gorm.DB) (*Dependencies, error) {
+func initDeps(cfg config.Config, db *gorm.DB) (*Dependencies, error) {
 	cfgzap := zap.NewProductionConfig()
 	cfgzap.OutputPaths = []string{"app.log", "stdout"}
 	logger, err := cfgzap.Build()
@@ -27,11 +32,66 @@ func initDeps(db *gorm.DB) (*Dependencies, error) {
 		return nil, err
 	}
 
-	userUsecase := usecase.NewUserUsecase(repository.NewUserRepository(db))
-	cashUsecase := usecase.NewCashUsecase(repository.NewCashRepository(db))
+	location, err := time.LoadLocation(cfg.TIME_ZONE)
+	if err != nil {
+		return nil, err
+	}
+
+	mail, err := mailer.New(mailer.Config{
+		Driver:   cfg.MailDriver,
+		From:     cfg.MailFrom,
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		Dir:      cfg.MailDir,
+	}, logger.Sugar().Infof)
+	if err != nil {
+		return nil, err
+	}
+
+	userUsecase := usecase.NewUserUsecase(
+		repository.NewUserRepository(db),
+		repository.NewTokenRepository(db),
+		repository.NewTwoFactorRepository(db),
+		repository.NewSecurityRepository(db),
+		repository.NewUnitOfWork(db),
+		mail,
+		usecase.AuthConfig{
+			AccessTTL:  cfg.AccessTokenTTL,
+			RefreshTTL: cfg.RefreshTokenTTL,
+			ResetTTL:   cfg.ResetTokenTTL,
+			AppURL:     cfg.AppURL,
+
+			ResetResendInterval: cfg.ResetResendInterval,
+
+			VerifyTTL:            cfg.VerifyTokenTTL,
+			VerifyResendInterval: cfg.VerifyResendInterval,
+			UnverifiedLogin:      cfg.UnverifiedLogin,
+
+			TwoFactorIssuer: cfg.TwoFactorIssuer,
+			ChallengeTTL:    cfg.TwoFactorChallengeTTL,
+
+			AccountFreeAttempts: cfg.LoginAccountFreeAttempts,
+			IPFreeAttempts:      cfg.LoginIPFreeAttempts,
+			FailureWindow:       cfg.LoginFailureWindow,
+			LockoutBase:         cfg.LoginLockoutBase,
+			LockoutMax:          cfg.LoginLockoutMax,
+
+			MailIPLimit:  cfg.MailIPLimit,
+			MailIPWindow: cfg.MailIPWindow,
+		},
+		logger.Sugar().Errorf,
+	)
+	cashUsecase := usecase.NewCashUsecase(
+		repository.NewCashRepository(db),
+		repository.NewUnitOfWork(db),
+		location,
+	)
 
 	return &Dependencies{
 		Logger:      logger,
+		Location:    location,
 		UserUsecase: userUsecase,
 		CashUsecase: cashUsecase,
 	}, nil
@@ -39,10 +99,15 @@ func initDeps(db *gorm.DB) (*Dependencies, error) {
 
 func NewRouter(cfg config.Config, db *gorm.DB) *gin.Engine {
 	r := gin.Default()
+	// Without trusted proxies the client IP is the connection's address,
+	// so X-Forwarded-For cannot be used to dodge or abuse login throttling.
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		panic(err)
+	}
 
 	r.Use(middleware.CORSMiddleware())
 
-	deps, err := initDeps(db)
+	deps, err := initDeps(cfg, db)
 	if err != nil {
 		panic(err)
 	}
@@ -52,28 +117,83 @@ func NewRouter(cfg config.Config, db *gorm.DB) *gin.Engine {
 
 	authHandler := handler.NewAuthHandler(deps.UserUsecase)
 	userHandler := handler.NewUserHandler(deps.UserUsecase)
-	cashHandler := handler.NewCashHandler(deps.CashUsecase)
+	cashHandler := handler.NewCashHandler(deps.CashUsecase, deps.Location, deps.Logger)
+
+	requireToken := middleware.JWTAuthMiddleware(cfg.JWT, deps.UserUsecase, deps.Logger)
 
 	// Auth router group
 	authGroup := r.Group("/auth")
 	{
 		authGroup.POST("/register", authHandler.Register)
 		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/login/2fa", authHandler.CompleteLogin)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/logout", requireToken, authHandler.Logout)
+		authGroup.POST("/forgot-password", authHandler.ForgotPassword)
+		authGroup.POST("/reset-password", authHandler.ResetPassword)
+		authGroup.GET("/verify-email", authHandler.VerifyEmail)
+		authGroup.POST("/resend-verification", authHandler.ResendVerification)
 	}
 
 	// API router group
 	apiGroup := r.Group("/api")
-	apiGroup.Use(middleware.JWTAuthMiddleware(cfg.JWT))
+	apiGroup.Use(requireToken)
 	{
 		// user router
 		apiGroup.GET("/profile", userHandler.GetProfile)
-		apiGroup.GET("/get-users", userHandler.GetUsers)
+		apiGroup.GET("/2fa", authHandler.GetTwoFactorStatus)
+		apiGroup.POST("/2fa/enroll", authHandler.EnrollTwoFactor)
+		apiGroup.POST("/2fa/confirm", authHandler.ConfirmTwoFactor)
+		apiGroup.POST("/2fa/recovery-codes", authHandler.RegenerateRecoveryCodes)
+		apiGroup.POST("/2fa/disable", authHandler.DisableTwoFactor)
+		apiGroup.GET("/get-users", middleware.RequirePermission(auth.PermUserRead), userHandler.GetUsers)
+		apiGroup.PUT("/users/:id/role", middleware.RequirePermission(auth.PermUserManage), userHandler.UpdateUserRole)
+		apiGroup.GET("/security-events", middleware.RequirePermission(auth.PermSecurityRead), userHandler.GetSecurityEvents)
 
 		// cash router
-		apiGroup.POST("/cash/transactions", cashHandler.CreateTransaction)
-		apiGroup.GET("/cash/transactions", cashHandler.GetTransactions)
-		apiGroup.GET("/cash/balance", cashHandler.GetBalance)
-		apiGroup.GET("/cash/categories", cashHandler.GetCategories)
+		canRead := middleware.RequirePermission(auth.PermCashRead)
+		canWrite := middleware.RequirePermission(auth.PermCashWrite)
+		canManage := middleware.RequirePermission(auth.PermCashManage)
+		canReopen := middleware.RequirePermission(auth.PermPeriodReopen)
+
+		apiGroup.GET("/cash/accounts", canRead, cashHandler.GetAccounts)
+		apiGroup.POST("/cash/accounts", canManage, cashHandler.CreateAccount)
+		apiGroup.PUT("/cash/accounts/:id", canManage, cashHandler.UpdateAccount)
+		apiGroup.POST("/cash/accounts/:id/archive", canManage, cashHandler.ArchiveAccount)
+		apiGroup.POST("/cash/transactions", canWrite, cashHandler.CreateTransaction)
+		apiGroup.GET("/cash/transactions", canRead, cashHandler.GetTransactions)
+		apiGroup.GET("/cash/transactions/export", canRead, cashHandler.ExportTransactions)
+		apiGroup.POST("/cash/transactions/import", canWrite, cashHandler.ImportTransactions)
+		apiGroup.PUT("/cash/transactions/:id", canWrite, cashHandler.UpdateTransaction)
+		apiGroup.POST("/cash/transactions/:id/void", canWrite, cashHandler.VoidTransaction)
+		apiGroup.POST("/cash/transfers", canWrite, cashHandler.CreateTransfer)
+		apiGroup.POST("/cash/statements/import", canWrite, cashHandler.ImportStatement)
+		apiGroup.GET("/cash/reconciliations", canRead, cashHandler.GetReconciliations)
+		apiGroup.POST("/cash/reconciliations", canWrite, cashHandler.CreateReconciliation)
+		apiGroup.GET("/cash/reconciliations/:id", canRead, cashHandler.GetReconciliation)
+		apiGroup.POST("/cash/reconciliations/:id/statement", canWrite, cashHandler.LoadReconciliationStatement)
+		apiGroup.POST("/cash/reconciliations/:id/match", canWrite, cashHandler.MatchReconciliation)
+		apiGroup.POST("/cash/reconciliations/:id/lines/:line_id/match", canWrite, cashHandler.MatchReconciliationLine)
+		apiGroup.DELETE("/cash/reconciliations/:id/lines/:line_id/match", canWrite, cashHandler.UnmatchReconciliationLine)
+		apiGroup.POST("/cash/reconciliations/:id/complete", canManage, cashHandler.CompleteReconciliation)
+		apiGroup.GET("/cash/counts", canRead, cashHandler.GetCashCounts)
+		apiGroup.POST("/cash/counts", canWrite, cashHandler.CountCash)
+		apiGroup.GET("/cash/counts/:id", canRead, cashHandler.GetCashCount)
+		apiGroup.POST("/cash/counts/:id/confirm", canWrite, cashHandler.ConfirmCashCount)
+		apiGroup.GET("/cash/periods", canRead, cashHandler.GetPeriodCloses)
+		apiGroup.POST("/cash/periods/close", canManage, cashHandler.ClosePeriod)
+		apiGroup.GET("/cash/periods/:id", canRead, cashHandler.GetPeriodClose)
+		apiGroup.POST("/cash/periods/:id/reopen", canReopen, cashHandler.ReopenPeriod)
+		apiGroup.GET("/cash/balance", canRead, cashHandler.GetBalance)
+		apiGroup.POST("/cash/balance/recalculate", canManage, cashHandler.RecalculateBalances)
+		apiGroup.GET("/cash/reports/summary", canRead, cashHandler.GetSummaryReport)
+		apiGroup.GET("/cash/reports/summary/export", canRead, cashHandler.ExportSummaryReport)
+		apiGroup.GET("/cash/reports/cash-book/export", canRead, cashHandler.ExportCashBook)
+		apiGroup.PUT("/cash/opening-balance", canManage, cashHandler.SetOpeningBalance)
+		apiGroup.GET("/cash/categories", canRead, cashHandler.GetCategories)
+		apiGroup.POST("/cash/categories", canManage, cashHandler.CreateCategory)
+		apiGroup.PUT("/cash/categories/:id", canManage, cashHandler.UpdateCategory)
+		apiGroup.POST("/cash/categories/:id/archive", canManage, cashHandler.ArchiveCategory)
 	}
 
 	return r
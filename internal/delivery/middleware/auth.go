@@ -1,18 +1,27 @@
 package middleware
 
 import (
+	"errors"
 	"go-project/internal/auth"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
-func JWTAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// TokenRevocations tells whether an access token was revoked, by its jti
+// or because all tokens of its user issued before some time were.
+type TokenRevocations interface {
+	IsTokenRevoked(userID uint, jti string, issuedAt time.Time) (bool, error)
+}
+
+// JWTAuthMiddleware lets requests with a valid, unrevoked access token
+// through. The token of a user who no longer exists counts as revoked.
+func JWTAuthMiddleware(jwtSecret string, revocations TokenRevocations, logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		jwtKey := []byte(viper.GetString("JWT_SECRET"))
 		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token is required"})
@@ -22,21 +31,38 @@ func JWTAuthMiddleware(jwtSecret string) gin.HandlerFunc {
 
 		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
-		claims := &auth.CustomClaims{}
-
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtKey, nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := auth.ParseToken(tokenString)
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		revoked, err := revocations.IsTokenRevoked(claims.UserID, claims.ID, issuedAt)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			revoked, err = true, nil
+		}
+		if err != nil {
+			logger.Error("checking token revocation", zap.Uint("user_id", claims.UserID), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check the token"})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			c.Abort()
+			return
+		}
+
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
+		c.Set("jti", claims.ID)
+		c.Set("token_expires_at", claims.ExpiresAt.Time)
 
 		c.Next()
 	}
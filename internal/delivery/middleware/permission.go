@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"go-project/internal/auth"
+	"go-project/internal/delivery/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission lets the request through only when the role that
+// JWTAuthMiddleware put in the context is granted permission.
+func RequirePermission(permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !auth.HasPermission(c.GetString("role"), permission) {
+			c.JSON(http.StatusForbidden, response.ErrorResponse{
+				Success: false,
+				Message: "Forbidden",
+				Errors:  map[string]string{"permission": "Role does not have permission " + string(permission)},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
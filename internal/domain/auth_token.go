@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+)
+
+// TokenPair is what a login or refresh returns. AccessToken keeps the
+// "token" key the login response always had.
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// RefreshToken is stored by hash only. Each refresh replaces the token with
+// a new one of the same family; presenting a token that was already
+// replaced means it leaked, and the whole family is revoked.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uint       `gorm:"not null;index"`
+	FamilyID  string     `gorm:"size:64;not null;index"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+func (t RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// RevokedToken denies an access token by its jti until the token would
+// have expired anyway.
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;size:64"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// Session identifies the access token a request was made with.
+type Session struct {
+	UserID    uint
+	JTI       string
+	ExpiresAt time.Time
+}
+
+// PasswordResetToken lets the holder of the emailed token set a new
+// password once, before ExpiresAt. Only the hash is stored.
+type PasswordResetToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	StatementFormatOFX     = "ofx"
+	StatementFormatMT940   = "mt940"
+	StatementFormatCAMT053 = "camt053"
+)
+
+// BankStatement is an electronic bank statement read from one of the
+// supported formats. Balances are nil when the file does not carry them.
+type BankStatement struct {
+	Format         string          `json:"format"`
+	AccountNumber  string          `json:"account_number,omitempty"`
+	Currency       string          `json:"currency,omitempty"`
+	Start          time.Time       `json:"start"`
+	End            time.Time       `json:"end"`
+	OpeningBalance *Money          `json:"opening_balance,omitempty" swaggertype:"string"`
+	ClosingBalance *Money          `json:"closing_balance,omitempty" swaggertype:"string"`
+	Lines          []StatementLine `json:"lines"`
+}
+
+// StatementLine is one booking on a bank statement. Amount is signed:
+// credits to the account are positive and debits negative. Reference is the
+// bank's own id for the booking, or a fingerprint of the line when the bank
+// gives none, and is what repeated imports are deduplicated by.
+type StatementLine struct {
+	Date        time.Time `json:"date"`
+	Amount      Money     `json:"amount" swaggertype:"string"`
+	Reference   string    `json:"reference"`
+	Description string    `json:"description"`
+}
+
+// StatementImport describes how statement lines become transactions of an
+// account. Lines whose direction has no category are reported as unmatched.
+type StatementImport struct {
+	AccountID     uint
+	InCategoryID  uint
+	OutCategoryID uint
+	CreatedBy     uint
+	DryRun        bool
+}
+
+const (
+	StatementLineCreated   = "created"
+	StatementLineSkipped   = "skipped"
+	StatementLineUnmatched = "unmatched"
+)
+
+type StatementLineResult struct {
+	StatementLine
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+	TransactionID uint   `json:"transaction_id,omitempty"`
+}
+
+// StatementImportResult reports what became of every statement line, along
+// with the balances the statement states. On a dry run Created counts the
+// lines that would be created.
+type StatementImportResult struct {
+	Format         string                `json:"format"`
+	AccountID      uint                  `json:"account_id"`
+	DryRun         bool                  `json:"dry_run"`
+	Created        int                   `json:"created"`
+	Skipped        int                   `json:"skipped"`
+	Unmatched      int                   `json:"unmatched"`
+	OpeningBalance *Money                `json:"opening_balance,omitempty" swaggertype:"string"`
+	ClosingBalance *Money                `json:"closing_balance,omitempty" swaggertype:"string"`
+	Lines          []StatementLineResult `json:"lines"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+)
+
+type CashAccount struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Name           string     `gorm:"size:100;not null" json:"name"`
+	Type           string     `gorm:"size:10;not null;check:type IN ('cash','bank','ewallet')" json:"type"`
+	Provider       string     `gorm:"size:50" json:"provider,omitempty"`
+	AccountNumber  string     `gorm:"size:50" json:"account_number,omitempty"`
+	OpeningBalance Money      `gorm:"type:numeric(15,2);default:0" json:"opening_balance" swaggertype:"string"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func IsValidAccountType(accountType string) bool {
+	return accountType == "cash" || accountType == "bank" || accountType == "ewallet"
+}
+
+func (a CashAccount) IsArchived() bool {
+	return a.ArchivedAt != nil
+}
@@ -6,10 +6,11 @@ import (
 
 type CashBalance struct {
 	ID             uint      `gorm:"primaryKey" json:"id"`
-	Date           time.Time `gorm:"unique;not null" json:"date"`
-	OpeningBalance float64   `gorm:"type:numeric(15,2);default:0" json:"opening_balance"`
-	TotalIn        float64   `gorm:"type:numeric(15,2);default:0" json:"total_in"`
-	TotalOut       float64   `gorm:"type:numeric(15,2);default:0" json:"total_out"`
-	ClosingBalance float64   `gorm:"type:numeric(15,2);default:0" json:"closing_balance"`
+	AccountID      uint      `gorm:"not null;default:0;uniqueIndex:idx_cash_balances_account_date" json:"account_id"`
+	Date           time.Time `gorm:"type:date;not null;uniqueIndex:idx_cash_balances_account_date" json:"date"`
+	OpeningBalance Money     `gorm:"type:numeric(15,2);default:0" json:"opening_balance" swaggertype:"string"`
+	TotalIn        Money     `gorm:"type:numeric(15,2);default:0" json:"total_in" swaggertype:"string"`
+	TotalOut       Money     `gorm:"type:numeric(15,2);default:0" json:"total_out" swaggertype:"string"`
+	ClosingBalance Money     `gorm:"type:numeric(15,2);default:0" json:"closing_balance" swaggertype:"string"`
 	CalculatedAt   time.Time `json:"calculated_at"`
 }
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// CashBook frames a period of the cash book of one account, or of every
+// account combined when AccountID is 0, split into calendar months. The
+// balances come from the stored daily balances; the transactions of the
+// period are read separately.
+type CashBook struct {
+	AccountID      uint            `json:"account_id,omitempty"`
+	Account        *CashAccount    `json:"account,omitempty"`
+	Start          time.Time       `json:"start"`
+	End            time.Time       `json:"end"`
+	OpeningBalance Money           `json:"opening_balance" swaggertype:"string"`
+	ClosingBalance Money           `json:"closing_balance" swaggertype:"string"`
+	Months         []CashBookMonth `json:"months"`
+}
+
+// CashBookMonth is the part of a cash book period falling in one calendar
+// month; the first and last months may be partial.
+type CashBookMonth struct {
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	OpeningBalance Money     `json:"opening_balance" swaggertype:"string"`
+	ClosingBalance Money     `json:"closing_balance" swaggertype:"string"`
+}
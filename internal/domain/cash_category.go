@@ -4,13 +4,59 @@ import (
 	"time"
 )
 
+// System category keys. The application books transfers and cash count
+// differences under these categories; users cannot pick them for their own
+// transactions.
+const (
+	SystemCategoryTransfer       = "transfer"
+	SystemCategoryCashDifference = "cash_difference"
+)
+
+// SystemCategories are the categories created for each system key when
+// none is marked with it yet.
+var SystemCategories = map[string]CashCategory{
+	SystemCategoryTransfer: {
+		Name:        "Transfer Antar Akun",
+		Type:        "both",
+		Description: "Pemindahan dana antar akun kas, tidak dihitung sebagai pemasukan atau pengeluaran",
+	},
+	SystemCategoryCashDifference: {
+		Name:        "Selisih Kas",
+		Type:        "both",
+		Description: "Selisih antara hitung kas fisik dan saldo buku",
+	},
+}
+
 type CashCategory struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Name        string    `gorm:"size:100;not null" json:"name"`
-	Type        string    `gorm:"size:10;not null;check:type IN ('in','out','both')" json:"type"`
-	Description string    `gorm:"type:text" json:"description,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Type        string     `gorm:"size:10;not null;check:type IN ('in','out','both')" json:"type"`
+	Description string     `gorm:"type:text" json:"description,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// SystemKey marks a category the application books its own entries
+	// under, one of the SystemCategory keys; it is nil for the others.
+	SystemKey *string `gorm:"size:30;uniqueIndex" json:"system_key,omitempty"`
 
 	Transactions []CashTransaction `gorm:"foreignKey:CategoryID" json:"transactions,omitempty"`
 }
+
+func IsValidCategoryType(categoryType string) bool {
+	return categoryType == "in" || categoryType == "out" || categoryType == "both"
+}
+
+func (c CashCategory) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
+func (c CashCategory) IsSystem() bool {
+	return c.SystemKey != nil
+}
+
+// Accepts reports whether a transaction of the given type ("in" or "out")
+// may be booked under this category.
+func (c CashCategory) Accepts(transactionType string) bool {
+	return c.Type == "both" || c.Type == transactionType
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	CashCountDraft     = "draft"
+	CashCountConfirmed = "confirmed"
+)
+
+// Denominations are the rupiah notes and coins a cash drawer is counted in,
+// largest first.
+var Denominations = []Money{
+	100000 * moneyScale, 50000 * moneyScale, 20000 * moneyScale, 10000 * moneyScale, 5000 * moneyScale,
+	2000 * moneyScale, 1000 * moneyScale, 500 * moneyScale, 200 * moneyScale, 100 * moneyScale,
+}
+
+// CashCount is a physical count of a cash account at the end of a day.
+// Difference is the counted total minus the closing balance of the book;
+// confirming the count books a nonzero difference as an adjustment
+// transaction.
+type CashCount struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	AccountID       uint       `gorm:"not null;index:idx_cash_counts_account_date" json:"account_id"`
+	Date            time.Time  `gorm:"type:date;not null;index:idx_cash_counts_account_date" json:"date"`
+	CountedTotal    Money      `gorm:"type:numeric(15,2);not null" json:"counted_total" swaggertype:"string"`
+	ExpectedBalance Money      `gorm:"type:numeric(15,2);not null" json:"expected_balance" swaggertype:"string"`
+	Difference      Money      `gorm:"type:numeric(15,2);not null" json:"difference" swaggertype:"string"`
+	Status          string     `gorm:"size:20;not null;default:'draft'" json:"status"`
+	Note            string     `gorm:"type:text" json:"note,omitempty"`
+	AdjustmentID    *uint      `json:"adjustment_id,omitempty"`
+	CountedBy       uint       `json:"counted_by"`
+	ConfirmedBy     *uint      `json:"confirmed_by,omitempty"`
+	ConfirmedAt     *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	Account    *CashAccount     `gorm:"foreignKey:AccountID" json:"account,omitempty"`
+	Items      []CashCountItem  `gorm:"foreignKey:CashCountID" json:"items"`
+	Adjustment *CashTransaction `gorm:"foreignKey:AdjustmentID" json:"adjustment,omitempty"`
+}
+
+func (c CashCount) IsConfirmed() bool {
+	return c.Status == CashCountConfirmed
+}
+
+// CashCountItem is the number of notes or coins of one denomination.
+type CashCountItem struct {
+	ID           uint  `gorm:"primaryKey" json:"id"`
+	CashCountID  uint  `gorm:"not null;index" json:"cash_count_id"`
+	Denomination Money `gorm:"type:numeric(15,2);not null" json:"denomination" swaggertype:"string"`
+	Quantity     int   `gorm:"not null" json:"quantity"`
+	Subtotal     Money `gorm:"type:numeric(15,2);not null" json:"subtotal" swaggertype:"string"`
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	PeriodClosed   = "closed"
+	PeriodReopened = "reopened"
+
+	PeriodEventClose  = "close"
+	PeriodEventReopen = "reopen"
+)
+
+// PeriodClose locks the days from PeriodStart to PeriodEnd (inclusive) of
+// every account against new, edited and voided transactions while its
+// status is closed. The balances and category totals of the period are
+// snapshotted when it is closed and never change afterwards, even when the
+// period is reopened; closing it again takes a new snapshot.
+type PeriodClose struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	PeriodStart  time.Time  `gorm:"type:date;not null;index" json:"period_start"`
+	PeriodEnd    time.Time  `gorm:"type:date;not null;index" json:"period_end"`
+	Status       string     `gorm:"size:20;not null;default:'closed'" json:"status"`
+	Note         string     `gorm:"type:text" json:"note,omitempty"`
+	ClosedBy     uint       `json:"closed_by"`
+	ClosedAt     time.Time  `json:"closed_at"`
+	ReopenedBy   *uint      `json:"reopened_by,omitempty"`
+	ReopenedAt   *time.Time `json:"reopened_at,omitempty"`
+	ReopenReason string     `gorm:"type:text" json:"reopen_reason,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	Balances   []PeriodBalanceSnapshot  `gorm:"foreignKey:PeriodCloseID" json:"balances,omitempty"`
+	Categories []PeriodCategorySnapshot `gorm:"foreignKey:PeriodCloseID" json:"categories,omitempty"`
+	Events     []PeriodEvent            `gorm:"foreignKey:PeriodCloseID" json:"events,omitempty"`
+}
+
+func (p PeriodClose) IsClosed() bool {
+	return p.Status == PeriodClosed
+}
+
+// PeriodBalanceSnapshot is one account's balance movement over a closed
+// period. TotalIn and TotalOut are income and expense; transfers between
+// accounts are kept apart as in the summary report.
+type PeriodBalanceSnapshot struct {
+	ID             uint   `gorm:"primaryKey" json:"-"`
+	PeriodCloseID  uint   `gorm:"not null;index" json:"-"`
+	AccountID      uint   `json:"account_id"`
+	AccountName    string `gorm:"size:100" json:"account_name"`
+	OpeningBalance Money  `gorm:"type:numeric(15,2);not null" json:"opening_balance" swaggertype:"string"`
+	TotalIn        Money  `gorm:"type:numeric(15,2);not null" json:"total_in" swaggertype:"string"`
+	TotalOut       Money  `gorm:"type:numeric(15,2);not null" json:"total_out" swaggertype:"string"`
+	TransferIn     Money  `gorm:"type:numeric(15,2);not null" json:"transfer_in" swaggertype:"string"`
+	TransferOut    Money  `gorm:"type:numeric(15,2);not null" json:"transfer_out" swaggertype:"string"`
+	ClosingBalance Money  `gorm:"type:numeric(15,2);not null" json:"closing_balance" swaggertype:"string"`
+}
+
+// PeriodCategorySnapshot is the income and expense of one category over a
+// closed period, across every account.
+type PeriodCategorySnapshot struct {
+	ID            uint   `gorm:"primaryKey" json:"-"`
+	PeriodCloseID uint   `gorm:"not null;index" json:"-"`
+	CategoryID    uint   `json:"category_id"`
+	CategoryName  string `gorm:"size:100" json:"category_name"`
+	TotalIn       Money  `gorm:"type:numeric(15,2);not null" json:"total_in" swaggertype:"string"`
+	TotalOut      Money  `gorm:"type:numeric(15,2);not null" json:"total_out" swaggertype:"string"`
+	Net           Money  `gorm:"type:numeric(15,2);not null" json:"net" swaggertype:"string"`
+}
+
+// PeriodEvent logs who closed or reopened a period, and why.
+type PeriodEvent struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	PeriodCloseID uint      `gorm:"not null;index" json:"period_close_id"`
+	Action        string    `gorm:"size:20;not null" json:"action"`
+	UserID        uint      `json:"user_id"`
+	Reason        string    `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	GroupByDay   = "day"
+	GroupByWeek  = "week"
+	GroupByMonth = "month"
+	GroupByYear  = "year"
+)
+
+// ReportRow is one aggregate produced by the repository: the in/out totals
+// of one kind of transaction in one category within one period.
+type ReportRow struct {
+	Period     time.Time
+	CategoryID uint
+	Kind       string
+	TotalIn    Money
+	TotalOut   Money
+}
+
+type CategoryTotal struct {
+	CategoryID   uint   `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	TotalIn      Money  `json:"total_in" swaggertype:"string"`
+	TotalOut     Money  `json:"total_out" swaggertype:"string"`
+	Net          Money  `json:"net" swaggertype:"string"`
+}
+
+// ReportBucket covers one period of a summary report. TotalIn and TotalOut
+// are income and expense; transfers between accounts are reported
+// separately but still move the running balance of a single account.
+type ReportBucket struct {
+	PeriodStart    time.Time       `json:"period_start"`
+	PeriodEnd      time.Time       `json:"period_end"`
+	TotalIn        Money           `json:"total_in" swaggertype:"string"`
+	TotalOut       Money           `json:"total_out" swaggertype:"string"`
+	Net            Money           `json:"net" swaggertype:"string"`
+	TransferIn     Money           `json:"transfer_in" swaggertype:"string"`
+	TransferOut    Money           `json:"transfer_out" swaggertype:"string"`
+	RunningBalance Money           `json:"running_balance" swaggertype:"string"`
+	Categories     []CategoryTotal `json:"categories,omitempty"`
+}
+
+type SummaryReport struct {
+	AccountID      uint            `json:"account_id,omitempty"`
+	Start          time.Time       `json:"start"`
+	End            time.Time       `json:"end"`
+	GroupBy        string          `json:"group_by"`
+	OpeningBalance Money           `json:"opening_balance" swaggertype:"string"`
+	TotalIn        Money           `json:"total_in" swaggertype:"string"`
+	TotalOut       Money           `json:"total_out" swaggertype:"string"`
+	Net            Money           `json:"net" swaggertype:"string"`
+	ClosingBalance Money           `json:"closing_balance" swaggertype:"string"`
+	Buckets        []ReportBucket  `json:"buckets"`
+	Categories     []CategoryTotal `json:"categories,omitempty"`
+}
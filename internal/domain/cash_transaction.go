@@ -4,19 +4,61 @@ import (
 	"time"
 )
 
+const (
+	TransactionKindRegular  = "regular"
+	TransactionKindTransfer = "transfer"
+)
+
 type CashTransaction struct {
-	ID              uint      `gorm:"primaryKey" json:"id"`
-	TransactionDate time.Time `gorm:"not null" json:"transaction_date"`
-	Type            string    `gorm:"size:10;not null;check:type IN ('in','out')" json:"type"`
-	CategoryID      uint      `json:"category_id"`
-	Description     string    `gorm:"type:text" json:"description"`
-	Amount          float64   `gorm:"type:numeric(15,2);not null" json:"amount"`
-	PaymentMethod   string    `gorm:"size:20;default:'cash'" json:"payment_method"`
-	ReferenceID     *uint     `json:"reference_id,omitempty"`
-	CreatedBy       uint      `json:"created_by"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	TransactionDate  time.Time  `gorm:"not null" json:"transaction_date"`
+	AccountID        uint       `gorm:"index;uniqueIndex:idx_cash_transactions_bank_reference,priority:1" json:"account_id"`
+	Type             string     `gorm:"size:10;not null;check:type IN ('in','out')" json:"type"`
+	Kind             string     `gorm:"size:20;not null;default:'regular'" json:"kind"`
+	CategoryID       uint       `json:"category_id"`
+	Description      string     `gorm:"type:text" json:"description"`
+	Amount           Money      `gorm:"type:numeric(15,2);not null" json:"amount" swaggertype:"string"`
+	PaymentMethod    string     `gorm:"size:20;default:'cash'" json:"payment_method"`
+	ReferenceID      *uint      `json:"reference_id,omitempty"`
+	ReversalOfID     *uint      `gorm:"index" json:"reversal_of_id,omitempty"`
+	BankReference    *string    `gorm:"size:100;uniqueIndex:idx_cash_transactions_bank_reference,priority:2" json:"bank_reference,omitempty"`
+	VoidedAt         *time.Time `json:"voided_at,omitempty"`
+	VoidedBy         *uint      `json:"voided_by,omitempty"`
+	VoidReason       string     `gorm:"type:text" json:"void_reason,omitempty"`
+	ReconciledAt     *time.Time `json:"reconciled_at,omitempty"`
+	ReconciliationID *uint      `gorm:"index" json:"reconciliation_id,omitempty"`
+	CreatedBy        uint       `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 
+	Account  *CashAccount  `gorm:"foreignKey:AccountID" json:"account,omitempty"`
 	Category *CashCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	User     *User         `gorm:"foreignKey:CreatedBy" json:"user,omitempty"`
 }
+
+func (t CashTransaction) IsVoided() bool {
+	return t.VoidedAt != nil
+}
+
+func (t CashTransaction) IsReversal() bool {
+	return t.ReversalOfID != nil
+}
+
+func (t CashTransaction) IsReconciled() bool {
+	return t.ReconciledAt != nil
+}
+
+// SignedAmount is the amount as it moves the account's balance: positive
+// for money in, negative for money out.
+func (t CashTransaction) SignedAmount() Money {
+	if t.Type == "in" {
+		return t.Amount
+	}
+	return -t.Amount
+}
+
+// IsTransfer reports whether the transaction is one leg of a transfer
+// between accounts; ReferenceID then points at the other leg.
+func (t CashTransaction) IsTransfer() bool {
+	return t.Kind == TransactionKindTransfer
+}
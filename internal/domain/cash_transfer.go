@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+)
+
+// CashTransfer moves money between two accounts. It is stored as a linked
+// pair of transactions: Out on the source account and In on the target.
+type CashTransfer struct {
+	FromAccountID   uint      `json:"from_account_id"`
+	ToAccountID     uint      `json:"to_account_id"`
+	Amount          Money     `json:"amount" swaggertype:"string"`
+	TransactionDate time.Time `json:"transaction_date"`
+	Description     string    `json:"description"`
+	CreatedBy       uint      `json:"created_by"`
+
+	Out *CashTransaction `json:"out,omitempty"`
+	In  *CashTransaction `json:"in,omitempty"`
+}
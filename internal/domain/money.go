@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of rupiah kept in sen (1/100 rupiah) so sums stay exact.
+// It is stored as numeric(15,2) and serialised to JSON as a decimal string.
+type Money int64
+
+const (
+	moneyScale = 100
+	// maxMoney is the largest absolute amount numeric(15,2) can hold.
+	maxMoney Money = 1e15 - 1
+)
+
+var (
+	ErrInvalidMoney  = errors.New("nominal tidak valid")
+	ErrMoneyScale    = errors.New("nominal maksimal memiliki 2 angka desimal")
+	ErrMoneyOverflow = errors.New("nominal melebihi batas")
+)
+
+func NewMoney(rupiah int64) Money {
+	return Money(rupiah * moneyScale)
+}
+
+// ParseMoney reads a plain decimal such as "1500", "-20.5" or "0.10".
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	whole, frac, hasFrac := strings.Cut(value, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidMoney
+	}
+	if hasFrac && frac == "" {
+		return 0, ErrInvalidMoney
+	}
+	if len(frac) > 2 {
+		if strings.TrimRight(frac[2:], "0") != "" {
+			return 0, ErrMoneyScale
+		}
+		frac = frac[:2]
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, ErrInvalidMoney
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if len(strings.TrimLeft(whole, "0")) > 13 {
+		return 0, ErrMoneyOverflow
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidMoney
+	}
+	cents := int64(0)
+	if frac != "" {
+		cents, _ = strconv.ParseInt((frac + "0")[:2], 10, 64)
+	}
+
+	m := Money(units*moneyScale + cents)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Rupiah returns the whole rupiah part, truncated towards zero.
+func (m Money) Rupiah() int64 {
+	return int64(m) / moneyScale
+}
+
+// Float64 is only meant for presentation, e.g. spreadsheet cells.
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+func (m Money) String() string {
+	sign := ""
+	abs := int64(m)
+	if abs < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, abs/moneyScale, abs%moneyScale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accepts both "12.50" and 12.50; numbers are parsed from
+// their literal text rather than through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	if m.Abs() > maxMoney {
+		return nil, ErrMoneyOverflow
+	}
+	return m.String(), nil
+}
+
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		*m = Money(math.Round(v * moneyScale))
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+func (m *Money) scanString(value string) error {
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Money
+		err   error
+	}{
+		{"1500", 150000, nil},
+		{"-20.5", -2050, nil},
+		{"0.10", 10, nil},
+		{".5", 50, nil},
+		{"+7", 700, nil},
+		{" 12.34 ", 1234, nil},
+		{"1.500", 150, nil},
+		{"0001", 100, nil},
+		{"9999999999999.99", maxMoney, nil},
+		{"", 0, ErrInvalidMoney},
+		{"-", 0, ErrInvalidMoney},
+		{"12.", 0, ErrInvalidMoney},
+		{"1,5", 0, ErrInvalidMoney},
+		{"1..2", 0, ErrInvalidMoney},
+		{"abc", 0, ErrInvalidMoney},
+		{"1e3", 0, ErrInvalidMoney},
+		{"0.125", 0, ErrMoneyScale},
+		{"10000000000000", 0, ErrMoneyOverflow},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseMoney(%q) error = %v, want %v", tt.value, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{150000, "1500.00"},
+		{-2050, "-20.50"},
+		{-5, "-0.05"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.money), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{Money(-123456)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"amount":"-1234.56"}`; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		json string
+		want Money
+		err  bool
+	}{
+		{`"12.50"`, 1250, false},
+		{`12.50`, 1250, false},
+		{`-3`, -300, false},
+		{`"0.1"`, 10, false},
+		{`0.30000000000000004`, 0, true},
+		{`"12,50"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, tt := range tests {
+		var m Money
+		err := json.Unmarshal([]byte(tt.json), &m)
+		if (err != nil) != tt.err {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.json, err, tt.err)
+			continue
+		}
+		if m != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.json, m, tt.want)
+		}
+	}
+
+	m := Money(99)
+	if err := json.Unmarshal([]byte("null"), &m); err != nil || m != 99 {
+		t.Errorf("Unmarshal(null) = %d, %v; want the value left alone", m, err)
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		value any
+		want  Money
+		err   bool
+	}{
+		{nil, 0, false},
+		{[]byte("1500.25"), 150025, false},
+		{"-20.50", -2050, false},
+		{int64(42), 4200, false},
+		{float64(0.1) + float64(0.2), 30, false},
+		{"bad", 0, true},
+		{true, 0, true},
+	}
+	for _, tt := range tests {
+		m := Money(1)
+		err := m.Scan(tt.value)
+		if (err != nil) != tt.err {
+			t.Errorf("Scan(%#v) error = %v, want error %v", tt.value, err, tt.err)
+			continue
+		}
+		if !tt.err && m != tt.want {
+			t.Errorf("Scan(%#v) = %d, want %d", tt.value, m, tt.want)
+		}
+	}
+}
+
+func TestMoneyValue(t *testing.T) {
+	value, err := Money(-2050).Value()
+	if err != nil || value != "-20.50" {
+		t.Errorf("Value() = %v, %v; want -20.50", value, err)
+	}
+	if _, err := (maxMoney + 1).Value(); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Value() above numeric(15,2) error = %v, want %v", err, ErrMoneyOverflow)
+	}
+}
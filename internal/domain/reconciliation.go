@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	ReconciliationOpen      = "open"
+	ReconciliationCompleted = "completed"
+
+	MatchExact  = "exact"
+	MatchFuzzy  = "fuzzy"
+	MatchManual = "manual"
+)
+
+// Reconciliation compares an account's book with its bank statement for a
+// period. Statement lines are matched to transactions while it is open;
+// completing it marks the matched transactions as reconciled.
+type Reconciliation struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	AccountID        uint       `gorm:"not null;index" json:"account_id"`
+	PeriodStart      time.Time  `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd        time.Time  `gorm:"type:date;not null" json:"period_end"`
+	StatementBalance Money      `gorm:"type:numeric(15,2);not null" json:"statement_balance" swaggertype:"string"`
+	Status           string     `gorm:"size:20;not null;default:'open'" json:"status"`
+	Note             string     `gorm:"type:text" json:"note,omitempty"`
+	CreatedBy        uint       `json:"created_by"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	CompletedBy      *uint      `json:"completed_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	Account *CashAccount         `gorm:"foreignKey:AccountID" json:"account,omitempty"`
+	Lines   []ReconciliationLine `gorm:"foreignKey:ReconciliationID" json:"lines,omitempty"`
+}
+
+func (r Reconciliation) IsCompleted() bool {
+	return r.Status == ReconciliationCompleted
+}
+
+// ReconciliationLine is a statement line loaded into a reconciliation.
+// Amount is signed like StatementLine.Amount. TransactionID is set once the
+// line is matched to a transaction of the book.
+type ReconciliationLine struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	ReconciliationID uint      `gorm:"not null;uniqueIndex:idx_reconciliation_lines_transaction,priority:1" json:"reconciliation_id"`
+	Date             time.Time `gorm:"type:date;not null" json:"date"`
+	Amount           Money     `gorm:"type:numeric(15,2);not null" json:"amount" swaggertype:"string"`
+	Reference        string    `gorm:"size:100" json:"reference,omitempty"`
+	Description      string    `gorm:"type:text" json:"description"`
+	TransactionID    *uint     `gorm:"uniqueIndex:idx_reconciliation_lines_transaction,priority:2" json:"transaction_id,omitempty"`
+	MatchType        string    `gorm:"size:10" json:"match_type,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func (l ReconciliationLine) IsMatched() bool {
+	return l.TransactionID != nil
+}
+
+// MatchOptions bounds how far a fuzzy match may stray from the statement
+// line in days and in amount.
+type MatchOptions struct {
+	DateTolerance   int
+	AmountTolerance Money
+}
+
+type ReconciliationMatch struct {
+	Line        ReconciliationLine `json:"line"`
+	Transaction CashTransaction    `json:"transaction"`
+}
+
+// ReconciliationReport explains the difference between the statement and
+// the book at the end of the period. The statement balance should equal the
+// book balance plus the statement lines missing from the book, minus the
+// transactions missing from the statement, plus the amount differences of
+// fuzzy matches; whatever is left is UnexplainedDifference.
+type ReconciliationReport struct {
+	Reconciliation          *Reconciliation       `json:"reconciliation"`
+	BookBalance             Money                 `json:"book_balance" swaggertype:"string"`
+	StatementBalance        Money                 `json:"statement_balance" swaggertype:"string"`
+	Difference              Money                 `json:"difference" swaggertype:"string"`
+	UnmatchedStatementTotal Money                 `json:"unmatched_statement_total" swaggertype:"string"`
+	UnmatchedBookTotal      Money                 `json:"unmatched_book_total" swaggertype:"string"`
+	MatchedDifference       Money                 `json:"matched_difference" swaggertype:"string"`
+	UnexplainedDifference   Money                 `json:"unexplained_difference" swaggertype:"string"`
+	Matched                 []ReconciliationMatch `json:"matched"`
+	UnmatchedStatementLines []ReconciliationLine  `json:"unmatched_statement_lines"`
+	UnmatchedTransactions   []CashTransaction     `json:"unmatched_transactions"`
+}
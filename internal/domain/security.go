@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+)
+
+// Login throttle scopes: failed logins are counted per account, keyed by
+// the email that was tried whether or not it exists, and per client IP.
+// ThrottleMailIP counts the requests per client IP that can send someone
+// an email, such as a password reset.
+const (
+	ThrottleAccount = "account"
+	ThrottleIP      = "ip"
+	ThrottleMailIP  = "mail_ip"
+)
+
+// LoginThrottle counts recent failed logins for one account or IP. Failures
+// older than the failure window no longer count; past the free attempts
+// every failure locks logins until LockedUntil, twice as long as before.
+type LoginThrottle struct {
+	ID            uint      `gorm:"primaryKey"`
+	Scope         string    `gorm:"size:10;not null;uniqueIndex:idx_login_throttle_key"`
+	Key           string    `gorm:"column:throttle_key;size:255;not null;uniqueIndex:idx_login_throttle_key"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+}
+
+// RetryAfter is how long logins stay locked after now, or zero.
+func (t LoginThrottle) RetryAfter(now time.Time) time.Duration {
+	if t.LockedUntil == nil || !t.LockedUntil.After(now) {
+		return 0
+	}
+	return t.LockedUntil.Sub(now)
+}
+
+const (
+	EventLoginSuccess     = "login_success"
+	EventLoginFailure     = "login_failure"
+	EventLoginBlocked     = "login_blocked"
+	EventTwoFactorFailure = "two_factor_failure"
+	EventLoginLocked      = "login_locked"
+)
+
+// SecurityEvent records a login attempt and its outcome. UserID is set
+// when the attempt could be tied to an existing user; Email is what was
+// typed in.
+type SecurityEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Type      string    `gorm:"size:30;not null;index" json:"type"`
+	UserID    *uint     `gorm:"index" json:"user_id"`
+	Email     string    `gorm:"size:255;index" json:"email"`
+	IP        string    `gorm:"size:64;index" json:"ip"`
+	UserAgent string    `gorm:"size:255" json:"user_agent"`
+	Detail    string    `gorm:"size:255" json:"detail"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// ClientInfo identifies where a login request came from.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// SecurityEventFilter narrows the security event log. Zero values leave a
+// field unfiltered; events are listed newest first, before BeforeID when
+// it is set.
+type SecurityEventFilter struct {
+	Type     string
+	UserID   uint
+	Email    string
+	IP       string
+	Since    time.Time
+	Until    time.Time
+	BeforeID uint
+	Limit    int
+}
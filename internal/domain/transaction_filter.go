@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	SortDateAsc    = "date_asc"
+	SortDateDesc   = "date_desc"
+	SortAmountAsc  = "amount_asc"
+	SortAmountDesc = "amount_desc"
+)
+
+var (
+	ErrInvalidCursor      = errors.New("cursor tidak valid")
+	ErrCursorSortMismatch = errors.New("cursor dibuat untuk urutan lain; mulai lagi dari halaman pertama")
+)
+
+// TransactionFilter narrows a transaction listing. Zero values leave a field
+// unfiltered; Start is inclusive and End exclusive.
+type TransactionFilter struct {
+	AccountID     uint
+	Start         time.Time
+	End           time.Time
+	Type          string
+	Kind          string
+	CategoryID    uint
+	PaymentMethod string
+	CreatedBy     uint
+	MinAmount     *Money
+	MaxAmount     *Money
+	Search        string
+	Sort          string
+	After         *TransactionCursor
+	Limit         int
+}
+
+// TransactionCursor marks the last row of a page: the sort it was listed
+// by, its value of the sort column and its ID.
+type TransactionCursor struct {
+	Sort   string    `json:"s"`
+	Date   time.Time `json:"d,omitempty"`
+	Amount Money     `json:"a,omitempty"`
+	ID     uint      `json:"id"`
+}
+
+func NewTransactionCursor(transaction CashTransaction, sort string) TransactionCursor {
+	cursor := TransactionCursor{Sort: sort, ID: transaction.ID}
+	switch sort {
+	case SortAmountAsc, SortAmountDesc:
+		cursor.Amount = transaction.Amount
+	default:
+		cursor.Date = transaction.TransactionDate
+	}
+	return cursor
+}
+
+func (c TransactionCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeTransactionCursor(value string) (*TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor TransactionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	switch cursor.Sort {
+	case SortDateAsc, SortDateDesc, SortAmountAsc, SortAmountDesc:
+	default:
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// TransactionSummary totals a filtered set of transactions.
+type TransactionSummary struct {
+	Count    int64 `json:"count"`
+	TotalIn  Money `json:"total_in" swaggertype:"string"`
+	TotalOut Money `json:"total_out" swaggertype:"string"`
+	Net      Money `json:"net" swaggertype:"string"`
+}
+
+type TransactionPage struct {
+	Transactions []CashTransaction  `json:"transactions"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
+	HasMore      bool               `json:"has_more"`
+	Limit        int                `json:"limit"`
+	Summary      TransactionSummary `json:"summary"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+// ImportMapping names the CSV header of each transaction field. Either Type
+// and Amount, a signed Amount alone (negative means out) or the separate In
+// and Out columns must be mapped. Category and Account accept a name or an
+// id; Account may be left unmapped when the import targets one account.
+type ImportMapping struct {
+	Date          string `json:"date" example:"Tanggal"`
+	Type          string `json:"type,omitempty" example:"Jenis"`
+	Amount        string `json:"amount,omitempty" example:"Nominal"`
+	In            string `json:"in,omitempty"`
+	Out           string `json:"out,omitempty"`
+	Category      string `json:"category" example:"Kategori"`
+	Description   string `json:"description,omitempty" example:"Keterangan"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+	Account       string `json:"account,omitempty"`
+}
+
+// ImportRow is one parsed data row of an import file. Category and Account
+// hold the cell as written until the usecase resolves them; Errors collects
+// every problem found with the row.
+type ImportRow struct {
+	Line        int
+	Transaction CashTransaction
+	Category    string
+	Account     string
+	Errors      []string
+}
+
+func (r ImportRow) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+type ImportRowResult struct {
+	Line        int              `json:"line"`
+	Valid       bool             `json:"valid"`
+	Errors      []string         `json:"errors,omitempty"`
+	Transaction *CashTransaction `json:"transaction,omitempty"`
+}
+
+// ImportResult reports an import row by row. On a dry run nothing is
+// written and Imported stays 0.
+type ImportResult struct {
+	DryRun   bool              `json:"dry_run"`
+	Total    int               `json:"total"`
+	Valid    int               `json:"valid"`
+	Invalid  int               `json:"invalid"`
+	Imported int               `json:"imported"`
+	Rows     []ImportRowResult `json:"rows"`
+}
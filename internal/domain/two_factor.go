@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+)
+
+// TwoFactor holds the TOTP secret of a user. Enrollment stores the secret
+// with ConfirmedAt nil; only after a valid code confirms it does login ask
+// for a second factor. LastUsedStep is the TOTP time step of the last
+// accepted code, so a code cannot be replayed within its window.
+type TwoFactor struct {
+	ID           uint   `gorm:"primaryKey"`
+	UserID       uint   `gorm:"not null;uniqueIndex"`
+	Secret       string `gorm:"size:64;not null"`
+	LastUsedStep int64  `gorm:"not null;default:0"`
+	ConfirmedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (t TwoFactor) IsEnabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// RecoveryCode can stand in for a TOTP code once, e.g. when the phone with
+// the authenticator is lost. Only the hash is stored.
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"size:64;not null;uniqueIndex"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// LoginChallenge is handed out after a correct password when the user has
+// two-factor authentication. Tokens are issued only once the challenge is
+// completed with a TOTP or recovery code, within a few attempts and before
+// ExpiresAt.
+type LoginChallenge struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TwoFactorChallenge is the login response of a user with two-factor
+// authentication, in place of a TokenPair.
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	Challenge         string `json:"challenge"`
+	ExpiresIn         int    `json:"expires_in"`
+}
+
+// TwoFactorEnrollment is shown once when enrolling: the secret, the same
+// secret as an otpauth URI, and that URI as a QR code PNG data URI.
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"`
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at"`
+	RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+}
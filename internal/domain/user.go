@@ -4,13 +4,44 @@ import (
 	"time"
 )
 
+const (
+	RoleOwner   = "owner"
+	RoleAdmin   = "admin"
+	RoleCashier = "cashier"
+	RoleViewer  = "viewer"
+)
+
 type User struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	Name      string    `gorm:"size:100;not null" json:"name"`
 	Email     string    `gorm:"size:100;unique" json:"email"`
 	Password  string    `gorm:"size:255;not null" json:"-"`
+	Role      string    `gorm:"size:20;not null;default:'viewer'" json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// EmailVerifiedAt stays nil until the user opens the link sent to
+	// their address; VerificationSentAt throttles resending that link,
+	// and PasswordResetSentAt sending password reset links.
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	VerificationSentAt  *time.Time `json:"-"`
+	PasswordResetSentAt *time.Time `json:"-"`
+
+	// TokensRevokedAt invalidates access tokens issued before it, e.g.
+	// after a role change.
+	TokensRevokedAt *time.Time `json:"-"`
+
 	CashTransactions []CashTransaction `gorm:"foreignKey:CreatedBy" json:"cash_transactions,omitempty"`
 }
+
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleOwner, RoleAdmin, RoleCashier, RoleViewer:
+		return true
+	}
+	return false
+}
+
+func (u User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
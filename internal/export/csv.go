@@ -0,0 +1,173 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"go-project/internal/domain"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDelimiter = fmt.Errorf("pemisah kolom tidak valid: gunakan comma, semicolon, tab atau pipe")
+
+// CSVOptions controls the dialect of CSV exports.
+type CSVOptions struct {
+	Delimiter    rune
+	NumberFormat NumberFormat
+	Location     *time.Location
+}
+
+// ParseDelimiter reads a delimiter option given by name or as the character
+// itself, defaulting to a comma.
+func ParseDelimiter(value string) (rune, error) {
+	switch value {
+	case "", "comma", ",":
+		return ',', nil
+	case "semicolon", ";":
+		return ';', nil
+	case "tab", "\t":
+		return '\t', nil
+	case "pipe", "|":
+		return '|', nil
+	}
+	return 0, ErrInvalidDelimiter
+}
+
+// csvText guards a user-entered cell against formula injection: a
+// spreadsheet would run a cell starting with =, +, -, @, a tab or a carriage
+// return as a formula, so those get a leading apostrophe. Amounts are
+// written as they are, a negative one is meant to read as a number.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func newCSVWriter(w io.Writer, options CSVOptions) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if options.Delimiter != 0 {
+		writer.Comma = options.Delimiter
+	}
+	return writer
+}
+
+// TransactionCSV writes transactions as CSV rows as they are handed to it.
+// csv.Writer buffers a few kilobytes at a time, so memory use stays flat no
+// matter how many rows are written.
+type TransactionCSV struct {
+	writer  *csv.Writer
+	options CSVOptions
+}
+
+var transactionCSVHeader = []string{
+	"ID", "Tanggal", "Akun", "Jenis", "Pencatatan", "Kategori", "Keterangan",
+	"Metode Pembayaran", "Masuk", "Keluar", "Status", "Referensi",
+}
+
+// NewTransactionCSV writes the header row to w and returns the writer for
+// the data rows.
+func NewTransactionCSV(w io.Writer, options CSVOptions) (*TransactionCSV, error) {
+	if options.Location == nil {
+		options.Location = time.Local
+	}
+	out := &TransactionCSV{writer: newCSVWriter(w, options), options: options}
+	if err := out.writer.Write(transactionCSVHeader); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (t *TransactionCSV) Write(transaction domain.CashTransaction) error {
+	var account, category string
+	if transaction.Account != nil {
+		account = transaction.Account.Name
+	}
+	if transaction.Category != nil {
+		category = transaction.Category.Name
+	}
+
+	var in, out string
+	if transaction.Type == "in" {
+		in = FormatMoney(transaction.Amount, t.options.NumberFormat)
+	} else {
+		out = FormatMoney(transaction.Amount, t.options.NumberFormat)
+	}
+
+	var reference string
+	switch {
+	case transaction.ReversalOfID != nil:
+		reference = strconv.FormatUint(uint64(*transaction.ReversalOfID), 10)
+	case transaction.ReferenceID != nil:
+		reference = strconv.FormatUint(uint64(*transaction.ReferenceID), 10)
+	}
+
+	return t.writer.Write([]string{
+		strconv.FormatUint(uint64(transaction.ID), 10),
+		formatDate(transaction.TransactionDate, t.options.Location),
+		csvText(account),
+		transactionTypeLabel(transaction.Type),
+		transaction.Kind,
+		csvText(category),
+		csvText(transaction.Description),
+		csvText(transaction.PaymentMethod),
+		in,
+		out,
+		transactionStatus(transaction),
+		reference,
+	})
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (t *TransactionCSV) Flush() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+// WriteSummaryCSV writes a summary report: an opening balance row, one row
+// per period (preceded by its category rows when the report has them) and a
+// closing total row.
+func WriteSummaryCSV(w io.Writer, report *domain.SummaryReport, options CSVOptions) error {
+	if options.Location == nil {
+		options.Location = time.Local
+	}
+	writer := newCSVWriter(w, options)
+	money := func(amount domain.Money) string {
+		return FormatMoney(amount, options.NumberFormat)
+	}
+	date := func(t time.Time) string {
+		return formatDate(t, options.Location)
+	}
+
+	rows := [][]string{
+		{"Periode Mulai", "Periode Akhir", "Kategori", "Masuk", "Keluar", "Selisih", "Transfer Masuk", "Transfer Keluar", "Saldo"},
+		{date(report.Start), "", "Saldo Awal", "", "", "", "", "", money(report.OpeningBalance)},
+	}
+	for _, bucket := range report.Buckets {
+		for _, category := range bucket.Categories {
+			rows = append(rows, []string{
+				date(bucket.PeriodStart), date(bucket.PeriodEnd), csvText(category.CategoryName),
+				money(category.TotalIn), money(category.TotalOut), money(category.Net), "", "", "",
+			})
+		}
+		rows = append(rows, []string{
+			date(bucket.PeriodStart), date(bucket.PeriodEnd), "",
+			money(bucket.TotalIn), money(bucket.TotalOut), money(bucket.Net),
+			money(bucket.TransferIn), money(bucket.TransferOut), money(bucket.RunningBalance),
+		})
+	}
+	rows = append(rows, []string{
+		date(report.Start), date(report.End), "Total",
+		money(report.TotalIn), money(report.TotalOut), money(report.Net), "", "", money(report.ClosingBalance),
+	})
+
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
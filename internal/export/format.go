@@ -0,0 +1,87 @@
+package export
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// NumberFormat selects how amounts are written in text exports.
+type NumberFormat string
+
+const (
+	// NumberFormatPlain writes amounts as 1234567.50, the format the API uses.
+	NumberFormatPlain NumberFormat = "plain"
+	// NumberFormatID writes amounts the Indonesian way, as 1.234.567,50.
+	NumberFormatID NumberFormat = "id"
+)
+
+var ErrInvalidNumberFormat = fmt.Errorf("format angka tidak valid: harus 'plain' atau 'id'")
+
+// ParseNumberFormat reads a number_format option, defaulting to plain.
+func ParseNumberFormat(value string) (NumberFormat, error) {
+	switch NumberFormat(value) {
+	case "", NumberFormatPlain:
+		return NumberFormatPlain, nil
+	case NumberFormatID:
+		return NumberFormatID, nil
+	}
+	return "", ErrInvalidNumberFormat
+}
+
+// FormatMoney writes amount with two decimals in the given number format.
+func FormatMoney(amount domain.Money, format NumberFormat) string {
+	plain := amount.String()
+	if format != NumberFormatID {
+		return plain
+	}
+
+	sign := ""
+	if strings.HasPrefix(plain, "-") {
+		sign, plain = "-", plain[1:]
+	}
+	whole, frac, _ := strings.Cut(plain, ".")
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String() + "," + frac
+}
+
+func transactionTypeLabel(transactionType string) string {
+	if transactionType == "in" {
+		return "Masuk"
+	}
+	return "Keluar"
+}
+
+func transactionStatus(transaction domain.CashTransaction) string {
+	switch {
+	case transaction.IsReversal():
+		return "Pembatalan"
+	case transaction.IsVoided():
+		return "Dibatalkan"
+	}
+	return ""
+}
+
+func formatDate(t time.Time, location *time.Location) string {
+	return t.In(location).Format(dateLayout)
+}
+
+var monthNames = [...]string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
+
+// monthName writes the month of t in Indonesian, e.g. "Maret 2025".
+func monthName(t time.Time) string {
+	return fmt.Sprintf("%s %d", monthNames[t.Month()-1], t.Year())
+}
@@ -0,0 +1,351 @@
+package export
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfMargin    = 10.0
+	pdfRowHeight = 6.0
+)
+
+// SignatureNames are printed under the signature blocks of the cash book
+// report; an empty name leaves a blank line to be filled in by hand.
+type SignatureNames struct {
+	Treasurer string
+	Chairman  string
+}
+
+// cashBookColumns are the widths in millimetres of the transaction table
+// on an A4 portrait page.
+var cashBookColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"No", 10, "C"},
+	{"Tanggal", 22, "C"},
+	{"Uraian", 70, "L"},
+	{"Masuk", 29, "R"},
+	{"Keluar", 29, "R"},
+	{"Saldo", 30, "R"},
+}
+
+// CashBookPDF renders the printable "Laporan Buku Kas": the opening balance,
+// every transaction with its running balance, subtotals per category, the
+// closing balance and signature blocks for the treasurer and chairman.
+type CashBookPDF struct {
+	pdf       *fpdf.Fpdf
+	book      *domain.CashBook
+	location  *time.Location
+	names     SignatureNames
+	translate func(string) string
+
+	row        int
+	balance    domain.Money
+	totalIn    domain.Money
+	totalOut   domain.Money
+	categories map[string]*domain.CategoryTotal
+	finished   bool
+}
+
+func NewCashBookPDF(book *domain.CashBook, location *time.Location, names SignatureNames) *CashBookPDF {
+	if location == nil {
+		location = time.Local
+	}
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.AliasNbPages("")
+
+	p := &CashBookPDF{
+		pdf:        pdf,
+		book:       book,
+		location:   location,
+		names:      names,
+		translate:  pdf.UnicodeTranslatorFromDescriptor(""),
+		balance:    book.OpeningBalance,
+		categories: make(map[string]*domain.CategoryTotal),
+	}
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin - 2)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Halaman %d dari {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	p.writeTitle()
+	p.writeTableHeader()
+	p.writeRow("", formatLongDate(book.Start), "Saldo Awal", "", "", p.money(book.OpeningBalance), true)
+	return p
+}
+
+func (p *CashBookPDF) writeTitle() {
+	pdf := p.pdf
+	account := "Semua Akun"
+	if p.book.Account != nil {
+		account = p.book.Account.Name
+	}
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "LAPORAN BUKU KAS", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 5, p.translate("Akun: "+account), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("Periode %s s.d. %s",
+		formatLongDate(p.book.Start), formatLongDate(p.book.End)), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+}
+
+func (p *CashBookPDF) writeTableHeader() {
+	pdf := p.pdf
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(217, 225, 242)
+	for _, column := range cashBookColumns {
+		pdf.CellFormat(column.width, pdfRowHeight, column.title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// ensureSpace starts a new page when less than height is left above the
+// bottom margin, repeating the table header when withHeader is set.
+func (p *CashBookPDF) ensureSpace(height float64, withHeader bool) {
+	_, pageHeight := p.pdf.GetPageSize()
+	if p.pdf.GetY()+height <= pageHeight-pdfMargin-6 {
+		return
+	}
+	p.pdf.AddPage()
+	if withHeader {
+		p.writeTableHeader()
+	}
+}
+
+func (p *CashBookPDF) writeRow(number, date, description, in, out, balance string, bold bool) {
+	p.ensureSpace(pdfRowHeight, true)
+	style := ""
+	if bold {
+		style = "B"
+	}
+	p.pdf.SetFont("Helvetica", style, 8)
+
+	values := []string{number, date, description, in, out, balance}
+	for i, column := range cashBookColumns {
+		text := p.fit(p.translate(values[i]), column.width-2)
+		p.pdf.CellFormat(column.width, pdfRowHeight, text, "1", 0, column.align, false, 0, "")
+	}
+	p.pdf.Ln(-1)
+}
+
+// Write adds a transaction row. Transactions must arrive in date order.
+func (p *CashBookPDF) Write(transaction domain.CashTransaction) error {
+	if p.finished {
+		return fmt.Errorf("laporan buku kas sudah selesai ditulis")
+	}
+
+	category := ""
+	if transaction.Category != nil {
+		category = transaction.Category.Name
+	}
+	description := transaction.Description
+	if category != "" {
+		description = category + " - " + description
+	}
+	if status := transactionStatus(transaction); status != "" {
+		description = "[" + status + "] " + description
+	}
+
+	total, ok := p.categories[category]
+	if !ok {
+		total = &domain.CategoryTotal{CategoryID: transaction.CategoryID, CategoryName: category}
+		p.categories[category] = total
+	}
+
+	var in, out string
+	if transaction.Type == "in" {
+		in = p.money(transaction.Amount)
+		p.balance += transaction.Amount
+		p.totalIn += transaction.Amount
+		total.TotalIn += transaction.Amount
+	} else {
+		out = p.money(transaction.Amount)
+		p.balance -= transaction.Amount
+		p.totalOut += transaction.Amount
+		total.TotalOut += transaction.Amount
+	}
+	total.Net = total.TotalIn - total.TotalOut
+
+	p.row++
+	p.writeRow(
+		strconv.Itoa(p.row),
+		transaction.TransactionDate.In(p.location).Format("02/01/2006"),
+		description, in, out, p.money(p.balance), false,
+	)
+	return p.pdf.Error()
+}
+
+// Finish writes the totals, the category subtotals and the signature
+// blocks. No more transactions can be written afterwards.
+func (p *CashBookPDF) Finish() error {
+	if p.finished {
+		return p.pdf.Error()
+	}
+	p.finished = true
+
+	p.writeRow("", "", "Jumlah", p.money(p.totalIn), p.money(p.totalOut), p.money(p.balance), true)
+	p.writeCategoryTotals()
+	p.writeClosing()
+	p.writeSignatures()
+	return p.pdf.Error()
+}
+
+func (p *CashBookPDF) writeCategoryTotals() {
+	pdf := p.pdf
+	totals := make([]domain.CategoryTotal, 0, len(p.categories))
+	for _, total := range p.categories {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].CategoryName < totals[j].CategoryName
+	})
+
+	widths := []float64{102, 29, 29, 30}
+	p.ensureSpace(pdfRowHeight*3+8, false)
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, pdfRowHeight, "Rekapitulasi per Kategori", "", 1, "L", false, 0, "")
+
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		for i, title := range []string{"Kategori", "Masuk", "Keluar", "Selisih"} {
+			pdf.CellFormat(widths[i], pdfRowHeight, title, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	header()
+	for _, total := range totals {
+		_, pageHeight := pdf.GetPageSize()
+		if pdf.GetY()+pdfRowHeight > pageHeight-pdfMargin-6 {
+			pdf.AddPage()
+			header()
+		}
+		name := total.CategoryName
+		if name == "" {
+			name = "Tanpa kategori"
+		}
+		pdf.SetFont("Helvetica", "", 8)
+		values := []string{p.fit(p.translate(name), widths[0]-2), p.money(total.TotalIn), p.money(total.TotalOut), p.money(total.Net)}
+		for i, value := range values {
+			align := "R"
+			if i == 0 {
+				align = "L"
+			}
+			pdf.CellFormat(widths[i], pdfRowHeight, value, "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+func (p *CashBookPDF) writeClosing() {
+	pdf := p.pdf
+	lines := []struct {
+		label  string
+		amount domain.Money
+	}{
+		{"Saldo Awal", p.book.OpeningBalance},
+		{"Total Masuk", p.totalIn},
+		{"Total Keluar", p.totalOut},
+		{"Saldo Akhir", p.balance},
+	}
+
+	p.ensureSpace(float64(len(lines))*pdfRowHeight+6, false)
+	pdf.Ln(6)
+	for i, line := range lines {
+		style := ""
+		if i == len(lines)-1 {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.CellFormat(40, pdfRowHeight, line.label, "", 0, "L", false, 0, "")
+		pdf.CellFormat(5, pdfRowHeight, ":", "", 0, "C", false, 0, "")
+		pdf.CellFormat(45, pdfRowHeight, "Rp "+p.money(line.amount), "", 1, "R", false, 0, "")
+	}
+}
+
+func (p *CashBookPDF) writeSignatures() {
+	pdf := p.pdf
+	const blockHeight = 45.0
+	p.ensureSpace(blockHeight+10, false)
+	pdf.Ln(10)
+
+	pageWidth, _ := pdf.GetPageSize()
+	half := (pageWidth - 2*pdfMargin) / 2
+	name := func(value string) string {
+		if strings.TrimSpace(value) == "" {
+			return "(..............................)"
+		}
+		return "( " + p.translate(value) + " )"
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(half, 5, "", "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 5, formatLongDate(p.book.End), "", 1, "C", false, 0, "")
+	pdf.CellFormat(half, 5, "Mengetahui,", "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 5, "", "", 1, "C", false, 0, "")
+	pdf.CellFormat(half, 5, "Ketua", "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 5, "Bendahara", "", 1, "C", false, 0, "")
+	pdf.Ln(22)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(half, 5, name(p.names.Chairman), "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 5, name(p.names.Treasurer), "", 1, "C", false, 0, "")
+}
+
+// WriteTo finishes the report if that has not been done yet and writes the
+// PDF to w.
+func (p *CashBookPDF) WriteTo(w io.Writer) (int64, error) {
+	if err := p.Finish(); err != nil {
+		return 0, err
+	}
+	counter := &countingWriter{w: w}
+	err := p.pdf.Output(counter)
+	return counter.n, err
+}
+
+func (p *CashBookPDF) money(amount domain.Money) string {
+	return FormatMoney(amount, NumberFormatID)
+}
+
+// fit shortens text with an ellipsis until it fits within width millimetres
+// in the current font.
+func (p *CashBookPDF) fit(text string, width float64) string {
+	if p.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && p.pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// formatLongDate writes t as an Indonesian date, e.g. "5 Maret 2025".
+func formatLongDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[t.Month()-1], t.Year())
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
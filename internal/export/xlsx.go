@@ -0,0 +1,363 @@
+package export
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	summarySheet = "Ringkasan"
+	moneyFormat  = `"Rp"#,##0.00;-"Rp"#,##0.00`
+)
+
+var cashBookHeader = []interface{}{
+	"Tanggal", "Akun", "Kategori", "Keterangan", "Metode Pembayaran", "Masuk", "Keluar", "Saldo", "Status",
+}
+
+// CashBookXLSX builds a cash book workbook: a summary sheet followed by one
+// sheet per month of the book. Month sheets are written through excelize's
+// stream writer, which spills to a temporary file once it grows large, so
+// transactions can be handed to Write one at a time in date order.
+type CashBookXLSX struct {
+	file     *excelize.File
+	book     *domain.CashBook
+	location *time.Location
+	styles   cashBookStyles
+
+	sheets   []cashBookSheet
+	stream   *excelize.StreamWriter
+	row      int
+	balance  domain.Money
+	finished bool
+}
+
+type cashBookStyles struct {
+	header, date, money, total, totalMoney int
+}
+
+// cashBookSheet remembers where a finished month sheet keeps its totals so
+// the summary sheet can refer to them.
+type cashBookSheet struct {
+	name     string
+	month    domain.CashBookMonth
+	totalRow int
+	totalIn  domain.Money
+	totalOut domain.Money
+	closing  domain.Money
+}
+
+func NewCashBookXLSX(book *domain.CashBook, location *time.Location) (*CashBookXLSX, error) {
+	if location == nil {
+		location = time.Local
+	}
+	file := excelize.NewFile()
+	x := &CashBookXLSX{file: file, book: book, location: location}
+
+	if err := file.SetSheetName("Sheet1", summarySheet); err != nil {
+		return nil, x.fail(err)
+	}
+	fullCalcOnLoad := true
+	if err := file.SetCalcProps(&excelize.CalcPropsOptions{FullCalcOnLoad: &fullCalcOnLoad}); err != nil {
+		return nil, x.fail(err)
+	}
+
+	var err error
+	styles := []struct {
+		id    *int
+		style excelize.Style
+	}{
+		{&x.styles.header, excelize.Style{
+			Font: &excelize.Font{Bold: true},
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9E1F2"}},
+		}},
+		{&x.styles.date, excelize.Style{CustomNumFmt: ptr("dd/mm/yyyy")}},
+		{&x.styles.money, excelize.Style{CustomNumFmt: ptr(moneyFormat)}},
+		{&x.styles.total, excelize.Style{Font: &excelize.Font{Bold: true}}},
+		{&x.styles.totalMoney, excelize.Style{Font: &excelize.Font{Bold: true}, CustomNumFmt: ptr(moneyFormat)}},
+	}
+	for _, s := range styles {
+		if *s.id, err = file.NewStyle(&s.style); err != nil {
+			return nil, x.fail(err)
+		}
+	}
+	return x, nil
+}
+
+// Write adds a transaction to the sheet of its month. Transactions must
+// arrive in date order.
+func (x *CashBookXLSX) Write(transaction domain.CashTransaction) error {
+	if x.finished {
+		return fmt.Errorf("buku kas sudah selesai ditulis")
+	}
+	day := transaction.TransactionDate.In(x.location)
+	for x.stream == nil || !day.Before(x.sheets[len(x.sheets)-1].month.End.AddDate(0, 0, 1)) {
+		if len(x.sheets) == len(x.book.Months) {
+			return fmt.Errorf("transaksi %d di luar periode buku kas", transaction.ID)
+		}
+		if err := x.nextMonth(); err != nil {
+			return err
+		}
+	}
+
+	var account, category string
+	if transaction.Account != nil {
+		account = transaction.Account.Name
+	}
+	if transaction.Category != nil {
+		category = transaction.Category.Name
+	}
+	var in, out interface{}
+	if transaction.Type == "in" {
+		in = excelize.Cell{StyleID: x.styles.money, Value: transaction.Amount.Float64()}
+		x.balance += transaction.Amount
+		x.sheets[len(x.sheets)-1].totalIn += transaction.Amount
+	} else {
+		out = excelize.Cell{StyleID: x.styles.money, Value: transaction.Amount.Float64()}
+		x.balance -= transaction.Amount
+		x.sheets[len(x.sheets)-1].totalOut += transaction.Amount
+	}
+
+	x.row++
+	return x.stream.SetRow(cellName(1, x.row), []interface{}{
+		excelize.Cell{StyleID: x.styles.date, Value: excelDate(day)},
+		account,
+		category,
+		transaction.Description,
+		transaction.PaymentMethod,
+		in,
+		out,
+		excelize.Cell{
+			StyleID: x.styles.money,
+			Formula: fmt.Sprintf("H%d+F%d-G%d", x.row-1, x.row, x.row),
+			Value:   x.balance.Float64(),
+		},
+		transactionStatus(transaction),
+	})
+}
+
+// nextMonth closes the current month sheet, if any, and starts the next
+// one with its header and opening balance rows.
+func (x *CashBookXLSX) nextMonth() error {
+	if err := x.closeMonth(); err != nil {
+		return err
+	}
+
+	month := x.book.Months[len(x.sheets)]
+	name := monthName(month.Start)
+	if _, err := x.file.NewSheet(name); err != nil {
+		return err
+	}
+	stream, err := x.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	x.stream = stream
+	x.sheets = append(x.sheets, cashBookSheet{name: name, month: month})
+	x.balance = month.OpeningBalance
+
+	widths := []float64{12, 18, 22, 40, 18, 18, 18, 18, 12}
+	for i, width := range widths {
+		if err := stream.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+	if err := stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+	if err := stream.SetRow("A1", cashBookHeader, excelize.RowOpts{StyleID: x.styles.header}); err != nil {
+		return err
+	}
+
+	x.row = 2
+	return stream.SetRow("A2", []interface{}{
+		excelize.Cell{StyleID: x.styles.date, Value: excelDate(month.Start)},
+		nil, nil,
+		excelize.Cell{StyleID: x.styles.total, Value: "Saldo Awal"},
+		nil, nil, nil,
+		excelize.Cell{StyleID: x.styles.totalMoney, Value: month.OpeningBalance.Float64()},
+	})
+}
+
+// closeMonth writes the formula totals of the current month sheet and
+// flushes it.
+func (x *CashBookXLSX) closeMonth() error {
+	if x.stream == nil {
+		return nil
+	}
+	sheet := &x.sheets[len(x.sheets)-1]
+	sheet.totalRow = x.row + 1
+	sheet.closing = x.balance
+
+	sum := func(column string, value domain.Money) excelize.Cell {
+		cell := excelize.Cell{StyleID: x.styles.totalMoney, Value: value.Float64()}
+		if x.row > 2 {
+			cell.Formula = fmt.Sprintf("SUM(%s3:%s%d)", column, column, x.row)
+		}
+		return cell
+	}
+	err := x.stream.SetRow(cellName(1, sheet.totalRow), []interface{}{
+		excelize.Cell{StyleID: x.styles.date, Value: excelDate(sheet.month.End)},
+		nil, nil,
+		excelize.Cell{StyleID: x.styles.total, Value: "Total / Saldo Akhir"},
+		nil,
+		sum("F", sheet.totalIn),
+		sum("G", sheet.totalOut),
+		excelize.Cell{StyleID: x.styles.totalMoney, Formula: fmt.Sprintf("H%d", x.row), Value: sheet.closing.Float64()},
+	})
+	if err != nil {
+		return err
+	}
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	x.stream = nil
+	return nil
+}
+
+// Finish writes the remaining month sheets and the summary sheet. No more
+// transactions can be written afterwards.
+func (x *CashBookXLSX) Finish() error {
+	if x.finished {
+		return nil
+	}
+	for len(x.sheets) < len(x.book.Months) {
+		if err := x.nextMonth(); err != nil {
+			return err
+		}
+	}
+	if err := x.closeMonth(); err != nil {
+		return err
+	}
+	if err := x.writeSummary(); err != nil {
+		return err
+	}
+	x.finished = true
+	return nil
+}
+
+// WriteTo finishes the workbook if that has not been done yet and writes it
+// to w.
+func (x *CashBookXLSX) WriteTo(w io.Writer) (int64, error) {
+	if err := x.Finish(); err != nil {
+		return 0, err
+	}
+	return x.file.WriteTo(w)
+}
+
+// writeSummary streams the summary sheet, whose cells refer to the totals
+// of the month sheets.
+func (x *CashBookXLSX) writeSummary() error {
+	stream, err := x.file.NewStreamWriter(summarySheet)
+	if err != nil {
+		return err
+	}
+
+	title := "Buku Kas Semua Akun"
+	if x.book.Account != nil {
+		title = "Buku Kas " + x.book.Account.Name
+	}
+	period := fmt.Sprintf("Periode %s s.d. %s",
+		x.book.Start.Format("02/01/2006"), x.book.End.Format("02/01/2006"))
+
+	if err := stream.SetColWidth(1, 1, 18); err != nil {
+		return err
+	}
+	if err := stream.SetColWidth(2, 5, 20); err != nil {
+		return err
+	}
+	if err := stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      4,
+		TopLeftCell: "A5",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	rows := [][]interface{}{
+		{excelize.Cell{StyleID: x.styles.total, Value: title}},
+		{period},
+		nil,
+	}
+	for i, row := range rows {
+		if err := stream.SetRow(cellName(1, i+1), row); err != nil {
+			return err
+		}
+	}
+	header := []interface{}{"Bulan", "Saldo Awal", "Masuk", "Keluar", "Saldo Akhir"}
+	if err := stream.SetRow("A4", header, excelize.RowOpts{StyleID: x.styles.header}); err != nil {
+		return err
+	}
+
+	money := func(style int, value domain.Money, formula string) excelize.Cell {
+		return excelize.Cell{StyleID: style, Value: value.Float64(), Formula: formula}
+	}
+	row := 4
+	var totalIn, totalOut domain.Money
+	for _, sheet := range x.sheets {
+		row++
+		ref := func(column string, row int) string {
+			return fmt.Sprintf("'%s'!%s%d", sheet.name, column, row)
+		}
+		totalIn += sheet.totalIn
+		totalOut += sheet.totalOut
+		err := stream.SetRow(cellName(1, row), []interface{}{
+			sheet.name,
+			money(x.styles.money, sheet.month.OpeningBalance, ref("H", 2)),
+			money(x.styles.money, sheet.totalIn, ref("F", sheet.totalRow)),
+			money(x.styles.money, sheet.totalOut, ref("G", sheet.totalRow)),
+			money(x.styles.money, sheet.closing, ref("H", sheet.totalRow)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	first, last := x.sheets[0], x.sheets[len(x.sheets)-1]
+	err = stream.SetRow(cellName(1, row+1), []interface{}{
+		excelize.Cell{StyleID: x.styles.total, Value: "Total"},
+		money(x.styles.totalMoney, first.month.OpeningBalance, "B5"),
+		money(x.styles.totalMoney, totalIn, fmt.Sprintf("SUM(C5:C%d)", row)),
+		money(x.styles.totalMoney, totalOut, fmt.Sprintf("SUM(D5:D%d)", row)),
+		money(x.styles.totalMoney, last.closing, fmt.Sprintf("E%d", row)),
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Flush()
+}
+
+// Close removes the temporary files of the workbook.
+func (x *CashBookXLSX) Close() error {
+	return x.file.Close()
+}
+
+func (x *CashBookXLSX) fail(err error) error {
+	_ = x.file.Close()
+	return err
+}
+
+// excelDate keeps the calendar day of t but drops its zone, since
+// spreadsheet dates have none.
+func excelDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func cellName(column, row int) string {
+	name, _ := excelize.CoordinatesToCellName(column, row)
+	return name
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,158 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"go-project/internal/domain"
+	"strings"
+	"time"
+)
+
+// camtDocument maps the parts of an ISO 20022 camt.053 bank-to-customer
+// statement that are imported. Element names are matched regardless of the
+// namespace version.
+type camtDocument struct {
+	Statements []struct {
+		Account struct {
+			IBAN  string `xml:"Id>IBAN"`
+			Other string `xml:"Id>Othr>Id"`
+			Ccy   string `xml:"Ccy"`
+		} `xml:"Acct"`
+		FromTo struct {
+			From string `xml:"FrDtTm"`
+			To   string `xml:"ToDtTm"`
+		} `xml:"FrToDt"`
+		Balances []struct {
+			Code   string     `xml:"Tp>CdOrPrtry>Cd"`
+			Amount camtAmount `xml:"Amt"`
+			Mark   string     `xml:"CdtDbtInd"`
+			Date   camtDate   `xml:"Dt"`
+		} `xml:"Bal"`
+		Entries []struct {
+			Amount        camtAmount `xml:"Amt"`
+			Mark          string     `xml:"CdtDbtInd"`
+			Reversal      bool       `xml:"RvslInd"`
+			BookingDate   camtDate   `xml:"BookgDt"`
+			ValueDate     camtDate   `xml:"ValDt"`
+			ServicerRef   string     `xml:"AcctSvcrRef"`
+			EntryRef      string     `xml:"NtryRef"`
+			AdditionalInf string     `xml:"AddtlNtryInf"`
+			Details       []struct {
+				EndToEndID   string   `xml:"Refs>EndToEndId"`
+				Unstructured []string `xml:"RmtInf>Ustrd"`
+				Creditor     string   `xml:"RltdPties>Cdtr>Nm"`
+				Debtor       string   `xml:"RltdPties>Dbtr>Nm"`
+			} `xml:"NtryDtls>TxDtls"`
+		} `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) parse(location *time.Location) (time.Time, error) {
+	value := d.Date
+	if value == "" {
+		value = d.DateTime
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseStatementDate(value, "2006-01-02", location)
+}
+
+// parseCAMT053 reads the entries of every statement in a camt.053 file.
+// The opening balance is the OPBD (or PRCD) balance of the first statement
+// and the closing balance the CLBD of the last one.
+func parseCAMT053(data []byte, location *time.Location) (*domain.BankStatement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("CAMT.053: %w", err)
+	}
+	statement := &domain.BankStatement{Format: domain.StatementFormatCAMT053}
+
+	for _, stmt := range document.Statements {
+		if statement.AccountNumber == "" {
+			statement.AccountNumber = stmt.Account.IBAN
+			if statement.AccountNumber == "" {
+				statement.AccountNumber = stmt.Account.Other
+			}
+			statement.Currency = stmt.Account.Ccy
+		}
+
+		for _, balance := range stmt.Balances {
+			amount, err := parseDecimal(balance.Amount.Value)
+			if err != nil {
+				return nil, fmt.Errorf("CAMT.053 saldo %s: %w", balance.Code, err)
+			}
+			if balance.Mark == "DBIT" {
+				amount = -amount
+			}
+			date, err := balance.Date.parse(location)
+			if err != nil {
+				return nil, fmt.Errorf("CAMT.053 saldo %s: %w", balance.Code, err)
+			}
+			switch balance.Code {
+			case "OPBD", "PRCD":
+				if statement.OpeningBalance == nil {
+					statement.OpeningBalance, statement.Start = &amount, date
+				}
+			case "CLBD":
+				statement.ClosingBalance, statement.End = &amount, date
+			}
+			if statement.Currency == "" {
+				statement.Currency = balance.Amount.Currency
+			}
+		}
+
+		for _, entry := range stmt.Entries {
+			amount, err := parseDecimal(entry.Amount.Value)
+			if err != nil {
+				return nil, fmt.Errorf("CAMT.053 entri %s: %w", entry.ServicerRef, err)
+			}
+			// A reversed entry undoes a booking in the opposite direction.
+			if (entry.Mark == "DBIT") != entry.Reversal {
+				amount = -amount
+			}
+			date, err := entry.BookingDate.parse(location)
+			if err == nil && date.IsZero() {
+				date, err = entry.ValueDate.parse(location)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("CAMT.053 entri %s: %w", entry.ServicerRef, err)
+			}
+			if date.IsZero() {
+				return nil, fmt.Errorf("CAMT.053 entri %s tanpa tanggal", entry.ServicerRef)
+			}
+
+			line := domain.StatementLine{Date: date, Amount: amount, Reference: entry.ServicerRef}
+			if line.Reference == "" {
+				line.Reference = entry.EntryRef
+			}
+			var parts []string
+			for _, details := range entry.Details {
+				if line.Reference == "" && details.EndToEndID != "" && details.EndToEndID != "NOTPROVIDED" {
+					line.Reference = details.EndToEndID
+				}
+				party := details.Creditor
+				if amount > 0 {
+					party = details.Debtor
+				}
+				parts = append(parts, nonEmpty(party, strings.Join(details.Unstructured, " "))...)
+			}
+			if len(parts) == 0 && entry.AdditionalInf != "" {
+				parts = append(parts, entry.AdditionalInf)
+			}
+			line.Description = strings.Join(strings.Fields(strings.Join(parts, " - ")), " ")
+			statement.Lines = append(statement.Lines, line)
+		}
+	}
+	return statement, nil
+}
@@ -0,0 +1,281 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/export"
+	"io"
+	"strings"
+	"time"
+)
+
+// MaxCSVRows caps the number of data rows a single import may carry.
+const MaxCSVRows = 5000
+
+var (
+	ErrMappingDateRequired     = errors.New("pemetaan kolom tanggal wajib diisi")
+	ErrMappingAmountRequired   = errors.New("pemetaan kolom nominal wajib diisi: amount, atau in dan out")
+	ErrMappingCategoryRequired = errors.New("pemetaan kolom kategori wajib diisi")
+	ErrEmptyFile               = errors.New("file CSV kosong")
+	ErrTooManyRows             = fmt.Errorf("file CSV melebihi %d baris", MaxCSVRows)
+	ErrInvalidDateFormat       = errors.New("format tanggal tidak valid: gunakan YYYY-MM-DD, DD/MM/YYYY, DD-MM-YYYY atau MM/DD/YYYY")
+)
+
+var dateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"DD-MM-YYYY": "02-01-2006",
+	"MM/DD/YYYY": "01/02/2006",
+}
+
+// CSVOptions describes the dialect of an import file.
+type CSVOptions struct {
+	Delimiter    rune
+	NumberFormat export.NumberFormat
+	// DateLayout is a Go time layout; see ParseDateFormat.
+	DateLayout string
+	Location   *time.Location
+}
+
+// ParseDateFormat turns a date format such as "DD/MM/YYYY" into a Go time
+// layout, defaulting to YYYY-MM-DD.
+func ParseDateFormat(value string) (string, error) {
+	if value == "" {
+		return dateFormats["YYYY-MM-DD"], nil
+	}
+	layout, ok := dateFormats[strings.ToUpper(value)]
+	if !ok {
+		return "", ErrInvalidDateFormat
+	}
+	return layout, nil
+}
+
+// ParseCSV reads a CSV file whose first row is a header, mapping columns to
+// transaction fields by header name. It checks the format of every cell and
+// records the problems on the row rather than stopping, so the caller can
+// report them all at once. Only a malformed file or mapping is an error.
+func ParseCSV(r io.Reader, mapping domain.ImportMapping, options CSVOptions) ([]domain.ImportRow, error) {
+	if err := validateMapping(mapping); err != nil {
+		return nil, err
+	}
+	if options.Location == nil {
+		options.Location = time.Local
+	}
+	if options.DateLayout == "" {
+		options.DateLayout = dateFormats["YYYY-MM-DD"]
+	}
+
+	reader := csv.NewReader(r)
+	if options.Delimiter != 0 {
+		reader.Comma = options.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns, err := mapColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isBlank(record) {
+			continue
+		}
+		if len(rows) == MaxCSVRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseRecord(line, record, columns, options))
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return rows, nil
+}
+
+func validateMapping(mapping domain.ImportMapping) error {
+	if mapping.Date == "" {
+		return ErrMappingDateRequired
+	}
+	if mapping.Amount == "" && (mapping.In == "" || mapping.Out == "") {
+		return ErrMappingAmountRequired
+	}
+	if mapping.Category == "" {
+		return ErrMappingCategoryRequired
+	}
+	return nil
+}
+
+// mapColumns finds the index of every mapped header, ignoring case and
+// surrounding spaces; unmapped fields get -1.
+func mapColumns(header []string, mapping domain.ImportMapping) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	fields := map[string]string{
+		"date":           mapping.Date,
+		"type":           mapping.Type,
+		"amount":         mapping.Amount,
+		"in":             mapping.In,
+		"out":            mapping.Out,
+		"category":       mapping.Category,
+		"description":    mapping.Description,
+		"payment_method": mapping.PaymentMethod,
+		"account":        mapping.Account,
+	}
+	columns := make(map[string]int, len(fields))
+	for field, name := range fields {
+		columns[field] = -1
+		if name == "" {
+			continue
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("kolom %q untuk %s tidak ditemukan di header CSV", name, field)
+		}
+		columns[field] = i
+	}
+	return columns, nil
+}
+
+func parseRecord(line int, record []string, columns map[string]int, options CSVOptions) domain.ImportRow {
+	row := domain.ImportRow{Line: line}
+	cell := func(field string) string {
+		i := columns[field]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	fail := func(format string, args ...any) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+
+	transaction := &row.Transaction
+	if value := cell("date"); value == "" {
+		fail("tanggal wajib diisi")
+	} else if date, err := time.ParseInLocation(options.DateLayout, value, options.Location); err != nil {
+		fail("tanggal %q tidak sesuai format", value)
+	} else {
+		transaction.TransactionDate = date
+	}
+
+	switch {
+	case columns["amount"] >= 0:
+		amount, err := ParseAmount(cell("amount"), options.NumberFormat)
+		if err != nil {
+			fail("nominal %q: %v", cell("amount"), err)
+			break
+		}
+		transaction.Amount = amount
+		if columns["type"] < 0 {
+			transaction.Type = "in"
+			if amount < 0 {
+				transaction.Type, transaction.Amount = "out", -amount
+			}
+		}
+	default:
+		in, out := cell("in"), cell("out")
+		switch {
+		case in != "" && out != "":
+			fail("hanya salah satu dari kolom masuk atau keluar yang boleh diisi")
+		case in == "" && out == "":
+			fail("nominal wajib diisi")
+		default:
+			value := in
+			transaction.Type = "in"
+			if out != "" {
+				transaction.Type, value = "out", out
+			}
+			amount, err := ParseAmount(value, options.NumberFormat)
+			if err != nil {
+				fail("nominal %q: %v", value, err)
+				break
+			}
+			transaction.Amount = amount
+		}
+	}
+
+	if columns["type"] >= 0 {
+		value := cell("type")
+		transactionType, ok := parseType(value)
+		if !ok {
+			fail("jenis transaksi %q tidak valid", value)
+		}
+		transaction.Type = transactionType
+	}
+
+	row.Category = cell("category")
+	if row.Category == "" {
+		fail("kategori wajib diisi")
+	}
+	row.Account = cell("account")
+	transaction.Description = cell("description")
+	transaction.PaymentMethod = cell("payment_method")
+	return row
+}
+
+// parseType accepts the transaction type in English or Indonesian.
+func parseType(value string) (string, bool) {
+	switch strings.ToLower(value) {
+	case "in", "masuk", "pemasukan", "kredit", "cr":
+		return "in", true
+	case "out", "keluar", "pengeluaran", "debit", "db", "dr":
+		return "out", true
+	}
+	return "", false
+}
+
+// ParseAmount reads an amount written in the given number format, allowing
+// an "Rp" prefix and spaces.
+func ParseAmount(value string, format export.NumberFormat) (domain.Money, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+	if trimmed := strings.TrimPrefix(strings.TrimPrefix(value, "Rp"), "rp"); trimmed != value {
+		value = strings.TrimPrefix(trimmed, ".")
+	}
+	value = strings.ReplaceAll(value, " ", "")
+
+	if format == export.NumberFormatID {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+	if negative {
+		value = "-" + value
+	}
+	return domain.ParseMoney(value)
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+package importer
+
+import (
+	"errors"
+	"go-project/internal/domain"
+	"go-project/internal/export"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCSV(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	basic := domain.ImportMapping{Date: "Tanggal", Amount: "Nominal", Category: "Kategori", Description: "Keterangan"}
+
+	type row struct {
+		line        int
+		date        string
+		txType      string
+		amount      domain.Money
+		category    string
+		account     string
+		description string
+		errors      int
+	}
+	tests := []struct {
+		name    string
+		input   string
+		mapping domain.ImportMapping
+		options CSVOptions
+		want    []row
+		err     error
+	}{
+		{
+			name:    "signed amounts",
+			input:   "Tanggal,Nominal,Kategori,Keterangan\n2024-01-02,150000,Penjualan,Kas masuk\n2024-01-03,-25000.50,Listrik,Token\n",
+			mapping: basic,
+			want: []row{
+				{line: 2, date: "2024-01-02", txType: "in", amount: 15000000, category: "Penjualan", description: "Kas masuk"},
+				{line: 3, date: "2024-01-03", txType: "out", amount: 2500050, category: "Listrik", description: "Token"},
+			},
+		},
+		{
+			name:    "header matched ignoring case, spaces and BOM",
+			input:   "\ufeff tanggal ;NOMINAL; Kategori\n02/01/2024;Rp 1.500.000,25;Gaji\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", Amount: "Nominal", Category: "kategori"},
+			options: CSVOptions{Delimiter: ';', NumberFormat: export.NumberFormatID, DateLayout: "02/01/2006"},
+			want: []row{
+				{line: 2, date: "2024-01-02", txType: "in", amount: 150000025, category: "Gaji"},
+			},
+		},
+		{
+			name:    "type column in Indonesian",
+			input:   "Tanggal,Jenis,Nominal,Kategori\n2024-01-02,Keluar,5000,Sewa\n2024-01-02,kredit,7000,Modal\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", Type: "Jenis", Amount: "Nominal", Category: "Kategori"},
+			want: []row{
+				{line: 2, date: "2024-01-02", txType: "out", amount: 500000, category: "Sewa"},
+				{line: 3, date: "2024-01-02", txType: "in", amount: 700000, category: "Modal"},
+			},
+		},
+		{
+			name:    "separate in and out columns",
+			input:   "Tanggal,Masuk,Keluar,Kategori,Akun\n2024-01-02,,1000,Air,Kas Tunai\n2024-01-02,2000,,Jasa,2\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", In: "Masuk", Out: "Keluar", Category: "Kategori", Account: "Akun"},
+			want: []row{
+				{line: 2, date: "2024-01-02", txType: "out", amount: 100000, category: "Air", account: "Kas Tunai"},
+				{line: 3, date: "2024-01-02", txType: "in", amount: 200000, category: "Jasa", account: "2"},
+			},
+		},
+		{
+			name:    "blank rows skipped, bad cells reported on the row",
+			input:   "Tanggal,Nominal,Kategori,Keterangan\n\n2024-13-01,abc,,\n,,,\n2024-01-05,10,Lain,\n",
+			mapping: basic,
+			want: []row{
+				{line: 3, errors: 3},
+				{line: 5, date: "2024-01-05", txType: "in", amount: 1000, category: "Lain"},
+			},
+		},
+		{
+			name:    "both in and out filled",
+			input:   "Tanggal,Masuk,Keluar,Kategori\n2024-01-02,1,2,Air\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", In: "Masuk", Out: "Keluar", Category: "Kategori"},
+			want:    []row{{line: 2, date: "2024-01-02", category: "Air", errors: 1}},
+		},
+		{
+			name:    "invalid type",
+			input:   "Tanggal,Jenis,Nominal,Kategori\n2024-01-02,transfer,1,Air\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", Type: "Jenis", Amount: "Nominal", Category: "Kategori"},
+			want:    []row{{line: 2, date: "2024-01-02", amount: 100, category: "Air", errors: 1}},
+		},
+		{
+			name:    "date mapping required",
+			input:   "Tanggal,Nominal,Kategori\n",
+			mapping: domain.ImportMapping{Amount: "Nominal", Category: "Kategori"},
+			err:     ErrMappingDateRequired,
+		},
+		{
+			name:    "amount mapping required",
+			input:   "Tanggal,Masuk,Kategori\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", In: "Masuk", Category: "Kategori"},
+			err:     ErrMappingAmountRequired,
+		},
+		{
+			name:    "category mapping required",
+			input:   "Tanggal,Nominal\n",
+			mapping: domain.ImportMapping{Date: "Tanggal", Amount: "Nominal"},
+			err:     ErrMappingCategoryRequired,
+		},
+		{
+			name:    "empty file",
+			input:   "",
+			mapping: basic,
+			err:     ErrEmptyFile,
+		},
+		{
+			name:    "header only",
+			input:   "Tanggal,Nominal,Kategori,Keterangan\n\n",
+			mapping: basic,
+			err:     ErrEmptyFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			options.Location = jakarta
+			rows, err := ParseCSV(strings.NewReader(tt.input), tt.mapping, options)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			var got []row
+			for _, r := range rows {
+				date := ""
+				if !r.Transaction.TransactionDate.IsZero() {
+					if r.Transaction.TransactionDate.Location() != jakarta {
+						t.Errorf("line %d: date not in the book's time zone", r.Line)
+					}
+					date = r.Transaction.TransactionDate.Format("2006-01-02")
+				}
+				got = append(got, row{
+					line:        r.Line,
+					date:        date,
+					txType:      r.Transaction.Type,
+					amount:      r.Transaction.Amount,
+					category:    r.Category,
+					account:     r.Account,
+					description: r.Transaction.Description,
+					errors:      len(r.Errors),
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %+v\nwant   %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVMissingColumn(t *testing.T) {
+	mapping := domain.ImportMapping{Date: "Tanggal", Amount: "Nominal", Category: "Kategori"}
+	_, err := ParseCSV(strings.NewReader("Tanggal,Jumlah,Kategori\n2024-01-02,1,Air\n"), mapping, CSVOptions{})
+	if err == nil || !strings.Contains(err.Error(), `"Nominal"`) {
+		t.Errorf("error = %v, want the missing column named", err)
+	}
+}
+
+func TestParseCSVTooManyRows(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("Tanggal,Nominal,Kategori\n")
+	for i := 0; i <= MaxCSVRows; i++ {
+		b.WriteString("2024-01-02,1,Air\n")
+	}
+	mapping := domain.ImportMapping{Date: "Tanggal", Amount: "Nominal", Category: "Kategori"}
+	if _, err := ParseCSV(strings.NewReader(b.String()), mapping, CSVOptions{}); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("error = %v, want %v", err, ErrTooManyRows)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value  string
+		format export.NumberFormat
+		want   domain.Money
+		err    bool
+	}{
+		{"1,234,567.50", export.NumberFormatPlain, 123456750, false},
+		{"1.234.567,50", export.NumberFormatID, 123456750, false},
+		{"Rp 25.000", export.NumberFormatID, 2500000, false},
+		{"Rp. 25.000", export.NumberFormatID, 2500000, false},
+		{"-Rp 1.000", export.NumberFormatID, -100000, false},
+		{"12,5", export.NumberFormatPlain, 12500, false},
+		{"", export.NumberFormatPlain, 0, true},
+		{"1,2,3", export.NumberFormatID, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.value, tt.format)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseAmount(%q, %s) error = %v, want error %v", tt.value, tt.format, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q, %s) = %d, want %d", tt.value, tt.format, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go-project/internal/domain"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var mt940Field = regexp.MustCompile(`^:([0-9]{2}[A-Z]?):(.*)$`)
+
+type mt940Tag struct {
+	tag, value string
+}
+
+// parseMT940 reads a SWIFT MT940 customer statement, possibly holding
+// several statement messages one after another.
+func parseMT940(data []byte, location *time.Location) (*domain.BankStatement, error) {
+	statement := &domain.BankStatement{Format: domain.StatementFormatMT940}
+
+	var line *domain.StatementLine
+	var supplementary string
+	finishLine := func() {
+		if line == nil {
+			return
+		}
+		if line.Description == "" {
+			line.Description = supplementary
+		}
+		statement.Lines = append(statement.Lines, *line)
+		line, supplementary = nil, ""
+	}
+
+	for _, field := range mt940Fields(data) {
+		var err error
+		switch field.tag {
+		case "25":
+			statement.AccountNumber = field.value
+		case "60F", "60M":
+			if statement.OpeningBalance == nil {
+				var balance domain.Money
+				var date time.Time
+				balance, date, statement.Currency, err = parseMT940Balance(field.value, location)
+				statement.OpeningBalance, statement.Start = &balance, date
+			}
+		case "62F", "62M":
+			finishLine()
+			var balance domain.Money
+			var date time.Time
+			balance, date, _, err = parseMT940Balance(field.value, location)
+			statement.ClosingBalance, statement.End = &balance, date
+		case "61":
+			finishLine()
+			line = &domain.StatementLine{}
+			supplementary, err = parseMT940Line(field.value, line, location)
+		case "86":
+			if line != nil {
+				line.Description = strings.Join(strings.Fields(field.value), " ")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("MT940 :%s: %w", field.tag, err)
+		}
+	}
+	finishLine()
+	return statement, nil
+}
+
+// mt940Fields splits the message text into its tagged fields, joining
+// continuation lines and skipping the SWIFT block wrappers.
+func mt940Fields(data []byte) []mt940Tag {
+	var fields []mt940Tag
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), MaxStatementSize)
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r ")
+		if match := mt940Field.FindStringSubmatch(text); match != nil {
+			fields = append(fields, mt940Tag{tag: match[1], value: match[2]})
+			continue
+		}
+		if text == "" || strings.HasPrefix(text, "-") || strings.HasPrefix(text, "{") || len(fields) == 0 {
+			continue
+		}
+		fields[len(fields)-1].value += "\n" + text
+	}
+	return fields
+}
+
+// parseMT940Balance reads a balance field: mark, date, currency and amount,
+// e.g. C260131IDR1500000,00.
+func parseMT940Balance(value string, location *time.Location) (domain.Money, time.Time, string, error) {
+	if len(value) < 11 {
+		return 0, time.Time{}, "", fmt.Errorf("saldo %q tidak valid", value)
+	}
+	mark := value[0]
+	date, err := parseStatementDate(value[1:7], "060102", location)
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+	amount, err := parseDecimal(value[10:])
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+	if mark == 'D' {
+		amount = -amount
+	}
+	return amount, date, value[7:10], nil
+}
+
+// parseMT940Line reads a :61: statement line into line and returns its
+// supplementary details, e.g.
+//
+//	2601020102D50000,00NTRFINV-12//B2601020001
+//	Pembayaran supplier
+func parseMT940Line(value string, line *domain.StatementLine, location *time.Location) (string, error) {
+	first, supplementary, _ := strings.Cut(value, "\n")
+	rest := first
+	if len(rest) < 6 {
+		return "", fmt.Errorf("baris %q tidak valid", value)
+	}
+	valueDate, err := parseStatementDate(rest[:6], "060102", location)
+	if err != nil {
+		return "", err
+	}
+	line.Date, rest = valueDate, rest[6:]
+
+	if len(rest) >= 4 && isDigitString(rest[:4]) {
+		entry, err := time.ParseInLocation("0102", rest[:4], location)
+		if err == nil {
+			entryDate := time.Date(valueDate.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, location)
+			// The entry date may fall in the year before or after the value date.
+			switch {
+			case entryDate.Sub(valueDate) > 180*24*time.Hour:
+				entryDate = entryDate.AddDate(-1, 0, 0)
+			case valueDate.Sub(entryDate) > 180*24*time.Hour:
+				entryDate = entryDate.AddDate(1, 0, 0)
+			}
+			line.Date = entryDate
+		}
+		rest = rest[4:]
+	}
+
+	var debit bool
+	switch {
+	case strings.HasPrefix(rest, "RC"):
+		debit, rest = true, rest[2:]
+	case strings.HasPrefix(rest, "RD"):
+		debit, rest = false, rest[2:]
+	case strings.HasPrefix(rest, "C"):
+		debit, rest = false, rest[1:]
+	case strings.HasPrefix(rest, "D"):
+		debit, rest = true, rest[1:]
+	default:
+		return "", fmt.Errorf("tanda debit/kredit pada %q tidak valid", first)
+	}
+	if rest != "" && (rest[0] < '0' || rest[0] > '9') {
+		rest = rest[1:] // funds code
+	}
+
+	end := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != ',' })
+	if end <= 0 {
+		return "", fmt.Errorf("nominal pada %q tidak valid", first)
+	}
+	amount, err := parseDecimal(rest[:end])
+	if err != nil {
+		return "", err
+	}
+	if debit {
+		amount = -amount
+	}
+	line.Amount, rest = amount, rest[end:]
+
+	if len(rest) >= 4 {
+		rest = rest[4:] // transaction type identification code
+	}
+	customer, bank, _ := strings.Cut(rest, "//")
+	customer, bank = strings.TrimSpace(customer), strings.TrimSpace(bank)
+	switch {
+	case bank != "":
+		line.Reference = bank
+	case customer != "" && !strings.EqualFold(customer, "NONREF"):
+		line.Reference = customer
+	}
+	return strings.Join(strings.Fields(supplementary), " "), nil
+}
+
+func isDigitString(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
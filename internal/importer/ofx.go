@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ofxTag = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+// parseOFX reads the bank statement of an OFX file. It walks the tags
+// without building a tree, which copes with both the SGML flavour of OFX 1.x,
+// where leaf elements are not closed, and the XML of OFX 2.x.
+func parseOFX(data []byte, location *time.Location) (*domain.BankStatement, error) {
+	statement := &domain.BankStatement{Format: domain.StatementFormatOFX}
+
+	var line *domain.StatementLine
+	var name, memo, aggregate string
+	finishLine := func() {
+		if line == nil {
+			return
+		}
+		line.Description = strings.TrimSpace(strings.Join(nonEmpty(name, memo), " - "))
+		statement.Lines = append(statement.Lines, *line)
+		line, name, memo = nil, "", ""
+	}
+
+	for _, match := range ofxTag.FindAllStringSubmatch(string(data), -1) {
+		closing, tag := match[1] == "/", strings.ToUpper(match[2])
+		value := html.UnescapeString(strings.TrimSpace(match[3]))
+
+		switch {
+		case tag == "STMTTRN" && !closing:
+			finishLine()
+			line = &domain.StatementLine{}
+			continue
+		case tag == "STMTTRN":
+			finishLine()
+			continue
+		case tag == "LEDGERBAL" || tag == "AVAILBAL":
+			aggregate = tag
+			if closing {
+				aggregate = ""
+			}
+			continue
+		case closing || value == "":
+			continue
+		}
+
+		var err error
+		if line != nil {
+			switch tag {
+			case "DTPOSTED":
+				line.Date, err = parseStatementDate(value, "20060102", location)
+			case "TRNAMT":
+				line.Amount, err = parseDecimal(value)
+			case "FITID":
+				line.Reference = value
+			case "NAME", "PAYEE":
+				name = value
+			case "MEMO":
+				memo = value
+			}
+		} else {
+			switch tag {
+			case "ACCTID":
+				statement.AccountNumber = value
+			case "CURDEF":
+				statement.Currency = value
+			case "DTSTART":
+				statement.Start, err = parseStatementDate(value, "20060102", location)
+			case "DTEND":
+				statement.End, err = parseStatementDate(value, "20060102", location)
+			case "BALAMT":
+				if aggregate == "LEDGERBAL" {
+					var balance domain.Money
+					balance, err = parseDecimal(value)
+					statement.ClosingBalance = &balance
+				}
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("OFX %s: %w", tag, err)
+		}
+	}
+	finishLine()
+
+	for _, line := range statement.Lines {
+		if line.Date.IsZero() {
+			return nil, fmt.Errorf("OFX: transaksi %q tanpa DTPOSTED", line.Reference)
+		}
+	}
+	return statement, nil
+}
+
+func nonEmpty(values ...string) []string {
+	result := values[:0:0]
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-project/internal/domain"
+	"io"
+	"strings"
+	"time"
+)
+
+// MaxStatementSize caps the size of a bank statement file.
+const MaxStatementSize = 10 << 20
+
+var (
+	ErrUnknownStatementFormat = errors.New("format mutasi bank tidak dikenali: gunakan ofx, mt940 atau camt053")
+	ErrEmptyStatement         = errors.New("file mutasi bank tidak berisi transaksi")
+)
+
+// ParseStatement reads a bank statement in the given format, detecting it
+// from the content when format is empty. Dates are read as calendar days in
+// location.
+func ParseStatement(r io.Reader, format string, location *time.Location) (*domain.BankStatement, error) {
+	if location == nil {
+		location = time.Local
+	}
+	data, err := io.ReadAll(io.LimitReader(r, MaxStatementSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxStatementSize {
+		return nil, fmt.Errorf("file mutasi bank melebihi %d MB", MaxStatementSize>>20)
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	if format == "" {
+		format = detectStatementFormat(data)
+	}
+
+	var statement *domain.BankStatement
+	switch strings.ToLower(format) {
+	case domain.StatementFormatOFX:
+		statement, err = parseOFX(data, location)
+	case domain.StatementFormatMT940:
+		statement, err = parseMT940(data, location)
+	case domain.StatementFormatCAMT053:
+		statement, err = parseCAMT053(data, location)
+	default:
+		return nil, ErrUnknownStatementFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(statement.Lines) == 0 {
+		return nil, ErrEmptyStatement
+	}
+	fillReferences(statement)
+	return statement, nil
+}
+
+func detectStatementFormat(data []byte) string {
+	head := string(data[:min(len(data), 4096)])
+	switch {
+	case strings.Contains(head, "OFXHEADER") || strings.Contains(strings.ToUpper(head), "<OFX>"):
+		return domain.StatementFormatOFX
+	case strings.Contains(head, "BkToCstmrStmt") || strings.Contains(head, "camt.053"):
+		return domain.StatementFormatCAMT053
+	case strings.Contains(head, ":20:") && strings.Contains(string(data), ":61:"):
+		return domain.StatementFormatMT940
+	}
+	return ""
+}
+
+// fillReferences gives every line without a bank reference a fingerprint of
+// its date, amount and description, numbered so identical lines on the same
+// statement stay apart.
+func fillReferences(statement *domain.BankStatement) {
+	seen := make(map[string]int)
+	for i := range statement.Lines {
+		line := &statement.Lines[i]
+		if line.Reference != "" {
+			continue
+		}
+		key := fmt.Sprintf("%s|%s|%s|%s", statement.AccountNumber,
+			line.Date.Format("2006-01-02"), line.Amount, strings.Join(strings.Fields(line.Description), " "))
+		seen[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		line.Reference = "sha1:" + hex.EncodeToString(sum[:])
+	}
+}
+
+// parseStatementDate reads the leading calendar day of a date written in
+// layout, such as the YYYYMMDD prefix of an OFX timestamp.
+func parseStatementDate(value, layout string, location *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < len(layout) {
+		return time.Time{}, fmt.Errorf("tanggal %q tidak valid", value)
+	}
+	date, err := time.ParseInLocation(layout, value[:len(layout)], location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tanggal %q tidak valid", value)
+	}
+	return date, nil
+}
+
+// parseDecimal reads an amount that uses either a point or a comma as the
+// decimal separator and no thousands separator.
+func parseDecimal(value string) (domain.Money, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	if strings.HasSuffix(value, ".") {
+		value += "0"
+	}
+	return domain.ParseMoney(value)
+}
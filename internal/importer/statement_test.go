@@ -0,0 +1,284 @@
+package importer
+
+import (
+	"errors"
+	"go-project/internal/domain"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>IDR
+<BANKACCTFROM><BANKID>014<ACCTID>1234567890<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20260101
+<DTEND>20260131
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260102120000[+7:WIB]
+<TRNAMT>1500000.00
+<FITID>TRX-001
+<NAME>PT Pelanggan
+<MEMO>Pelunasan INV-7 &amp; INV-8
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260105
+<TRNAMT>-25000,50
+<MEMO>Biaya admin
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>2475000.50<DTASOF>20260131</LEDGERBAL>
+<AVAILBAL><BALAMT>9.99<DTASOF>20260131</AVAILBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const ofxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>IDR</CURDEF>
+<BANKACCTFROM><ACCTID>998877</ACCTID></BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20260210</DTPOSTED><TRNAMT>75000</TRNAMT><FITID>A1</FITID><PAYEE>Budi</PAYEE></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+const mt940 = `{1:F01BANKIDJAXXXX0000000000}{4:
+:20:STMT2601
+:25:1234567890
+:28C:1/1
+:60F:C251231IDR1000000,00
+:61:2601020102C1500000,00NTRFINV-7//B2601020001
+Transfer masuk
+:86:Pelunasan
+ INV-7 PT Pelanggan
+:61:2512311231D25000,NCHGNONREF
+:86:Biaya admin
+:61:2601050105RD100,00NTRFNONREF
+:62F:C260131IDR2474900,00
+-}
+`
+
+const camt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+<BkToCstmrStmt><Stmt>
+<Acct><Id><Othr><Id>1234567890</Id></Othr></Id><Ccy>IDR</Ccy></Acct>
+<Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="IDR">1000000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2026-01-01</Dt></Dt></Bal>
+<Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="IDR">500.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Dt><Dt>2026-01-31</Dt></Dt></Bal>
+<Ntry>
+<Amt Ccy="IDR">1500000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+<BookgDt><Dt>2026-01-02</Dt></BookgDt>
+<AcctSvcrRef>SVC-1</AcctSvcrRef>
+<NtryDtls><TxDtls><RltdPties><Dbtr><Nm>PT Pelanggan</Nm></Dbtr><Cdtr><Nm>Kami</Nm></Cdtr></RltdPties><RmtInf><Ustrd>INV-7</Ustrd></RmtInf></TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+<Amt Ccy="IDR">2501000.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+<ValDt><DtTm>2026-01-20T10:00:00</DtTm></ValDt>
+<NtryDtls><TxDtls><Refs><EndToEndId>E2E-9</EndToEndId></Refs><RltdPties><Cdtr><Nm>Pemasok</Nm></Cdtr></RltdPties></TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+<Amt Ccy="IDR">10.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><RvslInd>true</RvslInd>
+<BookgDt><Dt>2026-01-21</Dt></BookgDt>
+<NtryRef>REV-1</NtryRef>
+<AddtlNtryInf>Koreksi</AddtlNtryInf>
+</Ntry>
+</Stmt></BkToCstmrStmt>
+</Document>
+`
+
+type testLine struct {
+	date        string
+	amount      domain.Money
+	reference   string
+	description string
+}
+
+func TestParseStatement(t *testing.T) {
+	location := time.FixedZone("WIB", 7*60*60)
+	money := func(m domain.Money) *domain.Money { return &m }
+
+	tests := []struct {
+		name          string
+		input         string
+		format        string
+		wantFormat    string
+		account       string
+		currency      string
+		start, end    string
+		opening       *domain.Money
+		closing       *domain.Money
+		lines         []testLine
+		fingerprinted []int
+	}{
+		{
+			name:       "OFX 1.x SGML",
+			input:      ofxSGML,
+			wantFormat: domain.StatementFormatOFX,
+			account:    "1234567890",
+			currency:   "IDR",
+			start:      "2026-01-01",
+			end:        "2026-01-31",
+			closing:    money(247500050),
+			lines: []testLine{
+				{"2026-01-02", 150000000, "TRX-001", "PT Pelanggan - Pelunasan INV-7 & INV-8"},
+				{"2026-01-05", -2500050, "", "Biaya admin"},
+			},
+			fingerprinted: []int{1},
+		},
+		{
+			name:       "OFX 2.x XML",
+			input:      ofxXML,
+			format:     "OFX",
+			wantFormat: domain.StatementFormatOFX,
+			account:    "998877",
+			currency:   "IDR",
+			lines:      []testLine{{"2026-02-10", 7500000, "A1", "Budi"}},
+		},
+		{
+			name:       "MT940",
+			input:      mt940,
+			wantFormat: domain.StatementFormatMT940,
+			account:    "1234567890",
+			currency:   "IDR",
+			start:      "2025-12-31",
+			end:        "2026-01-31",
+			opening:    money(100000000),
+			closing:    money(247490000),
+			lines: []testLine{
+				{"2026-01-02", 150000000, "B2601020001", "Pelunasan INV-7 PT Pelanggan"},
+				{"2025-12-31", -2500000, "", "Biaya admin"},
+				{"2026-01-05", 10000, "", ""},
+			},
+			fingerprinted: []int{1, 2},
+		},
+		{
+			name:       "CAMT.053",
+			input:      camt053,
+			wantFormat: domain.StatementFormatCAMT053,
+			account:    "1234567890",
+			currency:   "IDR",
+			start:      "2026-01-01",
+			end:        "2026-01-31",
+			opening:    money(100000000),
+			closing:    money(-50000),
+			lines: []testLine{
+				{"2026-01-02", 150000000, "SVC-1", "PT Pelanggan - INV-7"},
+				{"2026-01-20", -250100000, "E2E-9", "Pemasok"},
+				{"2026-01-21", -1000, "REV-1", "Koreksi"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := ParseStatement(strings.NewReader(tt.input), tt.format, location)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if statement.Format != tt.wantFormat || statement.AccountNumber != tt.account || statement.Currency != tt.currency {
+				t.Errorf("format, account, currency = %q, %q, %q; want %q, %q, %q",
+					statement.Format, statement.AccountNumber, statement.Currency, tt.wantFormat, tt.account, tt.currency)
+			}
+			if got := formatDay(statement.Start); got != tt.start {
+				t.Errorf("start = %q, want %q", got, tt.start)
+			}
+			if got := formatDay(statement.End); got != tt.end {
+				t.Errorf("end = %q, want %q", got, tt.end)
+			}
+			if !reflect.DeepEqual(statement.OpeningBalance, tt.opening) {
+				t.Errorf("opening balance = %v, want %v", statement.OpeningBalance, tt.opening)
+			}
+			if !reflect.DeepEqual(statement.ClosingBalance, tt.closing) {
+				t.Errorf("closing balance = %v, want %v", statement.ClosingBalance, tt.closing)
+			}
+
+			var lines []testLine
+			for i, line := range statement.Lines {
+				if line.Date.Location() != location {
+					t.Errorf("line %d: date not in the book's time zone", i)
+				}
+				reference := line.Reference
+				if strings.HasPrefix(reference, "sha1:") {
+					reference = ""
+				}
+				lines = append(lines, testLine{formatDay(line.Date), line.Amount, reference, line.Description})
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("lines = %+v\nwant    %+v", lines, tt.lines)
+			}
+			for _, i := range tt.fingerprinted {
+				if !strings.HasPrefix(statement.Lines[i].Reference, "sha1:") {
+					t.Errorf("line %d reference = %q, want a fingerprint", i, statement.Lines[i].Reference)
+				}
+			}
+		})
+	}
+}
+
+func TestParseStatementErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		format string
+		err    error
+	}{
+		{"unknown content", "Tanggal,Nominal\n2026-01-01,5\n", "", ErrUnknownStatementFormat},
+		{"unknown format", ofxSGML, "qif", ErrUnknownStatementFormat},
+		{"no transactions", "OFXHEADER:100\n<OFX><CURDEF>IDR</OFX>", "", ErrEmptyStatement},
+		{"OFX line without date", "<OFX><STMTTRN><TRNAMT>5<FITID>X</STMTTRN></OFX>", "", nil},
+		{"MT940 bad amount", ":20:X\n:25:1\n:61:260102CXYZ\n", "", nil},
+		{"CAMT.053 bad XML", "<Document><BkToCstmrStmt>", domain.StatementFormatCAMT053, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStatement(strings.NewReader(tt.input), tt.format, time.UTC)
+			if err == nil {
+				t.Fatal("want an error")
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// Identical lines without a bank reference get different fingerprints, and
+// the same file gets the same fingerprints every time it is read.
+func TestParseStatementFingerprints(t *testing.T) {
+	input := ":20:X\n:25:1\n:61:2601020102D100,00NCHGNONREF\n:86:Biaya\n:61:2601020102D100,00NCHGNONREF\n:86:Biaya\n"
+	parse := func() []string {
+		statement, err := ParseStatement(strings.NewReader(input), "", time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var references []string
+		for _, line := range statement.Lines {
+			references = append(references, line.Reference)
+		}
+		return references
+	}
+
+	first := parse()
+	if len(first) != 2 || first[0] == first[1] {
+		t.Fatalf("references = %v, want two different fingerprints", first)
+	}
+	if again := parse(); !reflect.DeepEqual(first, again) {
+		t.Errorf("references changed between reads: %v, then %v", first, again)
+	}
+}
+
+func formatDay(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// LogMailer writes messages to a log instead of sending them, for
+// development.
+type LogMailer struct {
+	logf func(format string, args ...any)
+}
+
+func NewLogMailer(logf func(format string, args ...any)) *LogMailer {
+	return &LogMailer{logf: logf}
+}
+
+func (m *LogMailer) Send(message Message) error {
+	m.logf("mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// FileMailer stores every message as an .eml file in a directory, where
+// tests and developers can pick it up.
+type FileMailer struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(message Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(message.To)
+	name := fmt.Sprintf("%s-%04d-%s.eml", time.Now().Format("20060102-150405"), m.seq.Add(1), recipient)
+	return os.WriteFile(filepath.Join(m.dir, name), format(m.from, message), 0o600)
+}
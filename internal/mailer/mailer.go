@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends plain-text email.
+type Mailer interface {
+	Send(message Message) error
+}
+
+// Config selects and sets up a Mailer; only the fields of the chosen
+// driver are used.
+type Config struct {
+	Driver   string
+	From     string
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Dir      string
+}
+
+func New(cfg Config, logf func(format string, args ...any)) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From), nil
+	case DriverFile:
+		return NewFileMailer(cfg.Dir, cfg.From), nil
+	case DriverLog, "":
+		return NewLogMailer(logf), nil
+	}
+	return nil, fmt.Errorf("unknown mail driver %q: must be smtp, log or file", cfg.Driver)
+}
+
+// format renders message as an RFC 5322 message with UTF-8 text.
+func format(from string, message Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
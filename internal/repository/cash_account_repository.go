@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+func (r *cashRepository) GetAllAccounts(includeArchived bool) ([]domain.CashAccount, error) {
+	var accounts []domain.CashAccount
+	query := r.db.Order("name asc")
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	err := query.Find(&accounts).Error
+	return accounts, err
+}
+
+func (r *cashRepository) GetAccountByID(id uint) (*domain.CashAccount, error) {
+	var account domain.CashAccount
+	err := r.db.First(&account, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &account, err
+}
+
+func (r *cashRepository) CreateAccount(account *domain.CashAccount) error {
+	return r.db.Create(account).Error
+}
+
+func (r *cashRepository) UpdateAccount(account *domain.CashAccount) error {
+	return r.db.Save(account).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *cashRepository) CreateCashCount(count *domain.CashCount) error {
+	return r.db.Omit("Account", "Adjustment").Create(count).Error
+}
+
+// GetCashCounts lists the counts between the start and end dates, latest
+// first; accountID 0 lists every account.
+func (r *cashRepository) GetCashCounts(accountID uint, start, end time.Time) ([]domain.CashCount, error) {
+	var counts []domain.CashCount
+	query := r.db.Preload("Account").Preload("Items", orderedCountItems).
+		Order("date desc").Order("id desc")
+	if accountID != 0 {
+		query = query.Where("account_id = ?", accountID)
+	}
+	if !start.IsZero() {
+		query = query.Where("date >= ?", start.Format("2006-01-02"))
+	}
+	if !end.IsZero() {
+		query = query.Where("date <= ?", end.Format("2006-01-02"))
+	}
+	err := query.Find(&counts).Error
+	return counts, err
+}
+
+// GetCashCountByID loads a count with its account, its denominations and
+// the adjustment booked when it was confirmed.
+func (r *cashRepository) GetCashCountByID(id uint) (*domain.CashCount, error) {
+	var count domain.CashCount
+	err := r.db.Preload("Account").Preload("Items", orderedCountItems).Preload("Adjustment").
+		First(&count, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &count, err
+}
+
+func (r *cashRepository) GetCashCountForUpdate(id uint) (*domain.CashCount, error) {
+	var count domain.CashCount
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&count, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &count, err
+}
+
+func (r *cashRepository) UpdateCashCount(count *domain.CashCount) error {
+	return r.db.Omit("Account", "Items", "Adjustment").Save(count).Error
+}
+
+func (r *cashRepository) HasConfirmedCashCount(accountID uint, date time.Time) (bool, error) {
+	var total int64
+	err := r.db.Model(&domain.CashCount{}).
+		Where("account_id = ? AND date = ? AND status = ?", accountID, date.Format("2006-01-02"), domain.CashCountConfirmed).
+		Count(&total).Error
+	return total > 0, err
+}
+
+func orderedCountItems(db *gorm.DB) *gorm.DB {
+	return db.Order("denomination desc")
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+)
+
+// StreamTransactions calls fn for every transaction matching filter, in the
+// filter's sort order, reading them from a database cursor one row at a time
+// instead of loading the whole result. Relations are not preloaded. The cursor
+// and limit of filter are ignored; the first error returned by fn stops the
+// scan and is returned.
+func (r *cashRepository) StreamTransactions(filter domain.TransactionFilter, fn func(domain.CashTransaction) error) error {
+	column, direction := transactionOrder(filter.Sort)
+
+	rows, err := filterTransactions(r.db.Model(&domain.CashTransaction{}), filter).
+		Order(column + " " + direction).
+		Order("id " + direction).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction domain.CashTransaction
+		if err := r.db.ScanRows(rows, &transaction); err != nil {
+			return err
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreatePeriodClose stores a closed period together with its snapshot.
+func (r *cashRepository) CreatePeriodClose(period *domain.PeriodClose) error {
+	return r.db.Omit("Events").Create(period).Error
+}
+
+func (r *cashRepository) GetPeriodCloses() ([]domain.PeriodClose, error) {
+	var periods []domain.PeriodClose
+	err := r.db.Order("period_start desc").Order("id desc").Find(&periods).Error
+	return periods, err
+}
+
+// GetPeriodCloseByID loads a period with its snapshot and its close and
+// reopen log.
+func (r *cashRepository) GetPeriodCloseByID(id uint) (*domain.PeriodClose, error) {
+	var period domain.PeriodClose
+	err := r.db.Preload("Balances", func(db *gorm.DB) *gorm.DB { return db.Order("account_id asc") }).
+		Preload("Categories", func(db *gorm.DB) *gorm.DB { return db.Order("category_name asc").Order("category_id asc") }).
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at asc").Order("id asc") }).
+		First(&period, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &period, err
+}
+
+func (r *cashRepository) GetPeriodCloseForUpdate(id uint) (*domain.PeriodClose, error) {
+	var period domain.PeriodClose
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &period, err
+}
+
+// GetClosedPeriods returns the periods currently locked, oldest first.
+func (r *cashRepository) GetClosedPeriods() ([]domain.PeriodClose, error) {
+	var periods []domain.PeriodClose
+	err := r.db.Where("status = ?", domain.PeriodClosed).Order("period_start asc").Find(&periods).Error
+	return periods, err
+}
+
+// ReopenPeriod only writes the reopen fields; the snapshot of a period is
+// never updated.
+func (r *cashRepository) ReopenPeriod(period *domain.PeriodClose) error {
+	return r.db.Model(period).Select("status", "reopened_by", "reopened_at", "reopen_reason", "updated_at").
+		Updates(period).Error
+}
+
+func (r *cashRepository) CreatePeriodEvent(event *domain.PeriodEvent) error {
+	return r.db.Create(event).Error
+}
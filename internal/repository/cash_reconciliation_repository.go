@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *cashRepository) CreateReconciliation(reconciliation *domain.Reconciliation) error {
+	return r.db.Omit("Account").Create(reconciliation).Error
+}
+
+func (r *cashRepository) GetReconciliations(accountID uint) ([]domain.Reconciliation, error) {
+	var reconciliations []domain.Reconciliation
+	query := r.db.Preload("Account").Order("period_end desc").Order("id desc")
+	if accountID != 0 {
+		query = query.Where("account_id = ?", accountID)
+	}
+	err := query.Find(&reconciliations).Error
+	return reconciliations, err
+}
+
+// GetReconciliationByID loads a reconciliation with its account and its
+// statement lines in date order.
+func (r *cashRepository) GetReconciliationByID(id uint) (*domain.Reconciliation, error) {
+	var reconciliation domain.Reconciliation
+	err := r.db.Preload("Account").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("date asc").Order("id asc") }).
+		First(&reconciliation, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &reconciliation, err
+}
+
+// GetReconciliationForUpdate locks the reconciliation row, so matching and
+// completing the same reconciliation run one after another.
+func (r *cashRepository) GetReconciliationForUpdate(id uint) (*domain.Reconciliation, error) {
+	var reconciliation domain.Reconciliation
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reconciliation, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.Where("reconciliation_id = ?", id).Order("date asc").Order("id asc").Find(&reconciliation.Lines).Error
+	return &reconciliation, err
+}
+
+func (r *cashRepository) UpdateReconciliation(reconciliation *domain.Reconciliation) error {
+	return r.db.Omit("Account", "Lines").Save(reconciliation).Error
+}
+
+func (r *cashRepository) CreateReconciliationLines(lines []domain.ReconciliationLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	return r.db.Create(&lines).Error
+}
+
+func (r *cashRepository) UpdateReconciliationLine(line *domain.ReconciliationLine) error {
+	return r.db.Save(line).Error
+}
+
+// GetReconciliationCandidates returns the transactions of the account
+// between the start and end instants that a statement line may be matched
+// to: neither voided nor reversals, and not confirmed by a reconciliation
+// other than reconciliationID.
+func (r *cashRepository) GetReconciliationCandidates(accountID uint, start, end time.Time, reconciliationID uint) ([]domain.CashTransaction, error) {
+	var transactions []domain.CashTransaction
+	err := r.db.Preload("Category").
+		Where("account_id = ? AND transaction_date >= ? AND transaction_date < ?", accountID, start, end).
+		Where("voided_at IS NULL AND reversal_of_id IS NULL").
+		Where("reconciliation_id IS NULL OR reconciliation_id = ?", reconciliationID).
+		Order("transaction_date asc").Order("id asc").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// MarkTransactionsReconciled records that the completed reconciliation
+// confirmed the transactions.
+func (r *cashRepository) MarkTransactionsReconciled(ids []uint, reconciliationID uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&domain.CashTransaction{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"reconciled_at": at, "reconciliation_id": reconciliationID}).Error
+}
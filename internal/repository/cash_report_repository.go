@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+	"time"
+)
+
+// AggregateTransactions sums the transactions matching filter in SQL, per
+// period (truncated to groupBy in the named time zone), category and kind.
+// Periods come back as midnight in that zone.
+func (r *cashRepository) AggregateTransactions(filter domain.TransactionFilter, groupBy string, location *time.Location) ([]domain.ReportRow, error) {
+	var rows []struct {
+		Period     time.Time
+		CategoryID uint
+		Kind       string
+		TotalIn    domain.Money
+		TotalOut   domain.Money
+	}
+
+	query := filterTransactions(r.db.Model(&domain.CashTransaction{}), filter)
+	err := query.Select(
+		"date_trunc(?, transaction_date AT TIME ZONE ?) AS period, category_id, kind, "+
+			"COALESCE(SUM(CASE WHEN type = 'in' THEN amount ELSE 0 END), 0) AS total_in, "+
+			"COALESCE(SUM(CASE WHEN type = 'out' THEN amount ELSE 0 END), 0) AS total_out",
+		groupBy, location.String(),
+	).Group("1, 2, 3").Order("1").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ReportRow, 0, len(rows))
+	for _, row := range rows {
+		// The truncated value is a wall-clock time without a zone.
+		year, month, day := row.Period.Date()
+		result = append(result, domain.ReportRow{
+			Period:     time.Date(year, month, day, 0, 0, 0, 0, location),
+			CategoryID: row.CategoryID,
+			Kind:       row.Kind,
+			TotalIn:    row.TotalIn,
+			TotalOut:   row.TotalOut,
+		})
+	}
+	return result, nil
+}
@@ -2,17 +2,69 @@ package repository
 
 import (
 	"go-project/internal/domain"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// balanceLockKey identifies the advisory locks that serialise writers of an
+// account's cash_balances within a database transaction.
+const balanceLockKey = 7_301_001
+
 type CashRepository interface {
 	CreateTransaction(transaction *domain.CashTransaction) error
-	GetTransactions(start, end time.Time) ([]domain.CashTransaction, error)
-	GetBalanceByDate(date time.Time) (*domain.CashBalance, error)
+	GetTransactionByID(id uint) (*domain.CashTransaction, error)
+	GetTransactionForUpdate(id uint) (*domain.CashTransaction, error)
+	UpdateTransaction(transaction *domain.CashTransaction) error
+	GetTransactions(accountID uint, start, end time.Time) ([]domain.CashTransaction, error)
+	ListTransactions(filter domain.TransactionFilter) ([]domain.CashTransaction, error)
+	StreamTransactions(filter domain.TransactionFilter, fn func(domain.CashTransaction) error) error
+	GetBankReferences(accountID uint, references []string) ([]string, error)
+	CreateReconciliation(reconciliation *domain.Reconciliation) error
+	GetReconciliations(accountID uint) ([]domain.Reconciliation, error)
+	GetReconciliationByID(id uint) (*domain.Reconciliation, error)
+	GetReconciliationForUpdate(id uint) (*domain.Reconciliation, error)
+	UpdateReconciliation(reconciliation *domain.Reconciliation) error
+	CreateReconciliationLines(lines []domain.ReconciliationLine) error
+	UpdateReconciliationLine(line *domain.ReconciliationLine) error
+	GetReconciliationCandidates(accountID uint, start, end time.Time, reconciliationID uint) ([]domain.CashTransaction, error)
+	MarkTransactionsReconciled(ids []uint, reconciliationID uint, at time.Time) error
+	CreateCashCount(count *domain.CashCount) error
+	GetCashCounts(accountID uint, start, end time.Time) ([]domain.CashCount, error)
+	GetCashCountByID(id uint) (*domain.CashCount, error)
+	GetCashCountForUpdate(id uint) (*domain.CashCount, error)
+	UpdateCashCount(count *domain.CashCount) error
+	HasConfirmedCashCount(accountID uint, date time.Time) (bool, error)
+	CreatePeriodClose(period *domain.PeriodClose) error
+	GetPeriodCloses() ([]domain.PeriodClose, error)
+	GetPeriodCloseByID(id uint) (*domain.PeriodClose, error)
+	GetPeriodCloseForUpdate(id uint) (*domain.PeriodClose, error)
+	GetClosedPeriods() ([]domain.PeriodClose, error)
+	ReopenPeriod(period *domain.PeriodClose) error
+	CreatePeriodEvent(event *domain.PeriodEvent) error
+	SummarizeTransactions(filter domain.TransactionFilter) (domain.TransactionSummary, error)
+	AggregateTransactions(filter domain.TransactionFilter, groupBy string, location *time.Location) ([]domain.ReportRow, error)
+	SumTransactionsByKind(accountID uint, kind string, start, end time.Time) (totalIn, totalOut domain.Money, err error)
+	GetBalanceByDate(accountID uint, date time.Time) (*domain.CashBalance, error)
+	GetLastBalanceBefore(accountID uint, date time.Time) (*domain.CashBalance, error)
+	GetBalancesFrom(accountID uint, date time.Time) ([]domain.CashBalance, error)
 	SaveOrUpdateBalance(balance *domain.CashBalance) error
-	GetAllCategories() ([]domain.CashCategory, error)
+	LockBalances(accountID uint) error
+	ApplyBalanceDelta(accountID uint, date time.Time, totalIn, totalOut domain.Money) error
+	ShiftBalancesFrom(accountID uint, date time.Time, amount domain.Money) error
+	GetOpeningBalance(accountID uint, date time.Time) (domain.Money, error)
+	GetAllAccounts(includeArchived bool) ([]domain.CashAccount, error)
+	GetAccountByID(id uint) (*domain.CashAccount, error)
+	CreateAccount(account *domain.CashAccount) error
+	UpdateAccount(account *domain.CashAccount) error
+	GetAllCategories(includeArchived bool) ([]domain.CashCategory, error)
+	GetCategoryByID(id uint) (*domain.CashCategory, error)
+	GetSystemCategory(key string) (*domain.CashCategory, error)
+	CreateCategory(category *domain.CashCategory) error
+	UpdateCategory(category *domain.CashCategory) error
+	CountCategoryTransactions(categoryID uint, transactionType string) (int64, error)
 }
 
 type cashRepository struct {
@@ -27,27 +79,204 @@ func (r *cashRepository) CreateTransaction(transaction *domain.CashTransaction)
 	return r.db.Create(transaction).Error
 }
 
-func (r *cashRepository) GetTransactions(start, end time.Time) ([]domain.CashTransaction, error) {
+func (r *cashRepository) GetTransactionByID(id uint) (*domain.CashTransaction, error) {
+	var transaction domain.CashTransaction
+	err := r.db.Preload("Category").Preload("Account").First(&transaction, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &transaction, err
+}
+
+// GetTransactionForUpdate reads a transaction and locks its row until the
+// surrounding database transaction ends.
+func (r *cashRepository) GetTransactionForUpdate(id uint) (*domain.CashTransaction, error) {
+	var transaction domain.CashTransaction
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &transaction, err
+}
+
+func (r *cashRepository) UpdateTransaction(transaction *domain.CashTransaction) error {
+	return r.db.Omit("Category", "Account", "User").Save(transaction).Error
+}
+
+// GetTransactions returns the transactions in [start, end); accountID 0
+// means every account.
+func (r *cashRepository) GetTransactions(accountID uint, start, end time.Time) ([]domain.CashTransaction, error) {
+	return r.ListTransactions(domain.TransactionFilter{
+		AccountID: accountID,
+		Start:     start,
+		End:       end,
+		Sort:      domain.SortDateAsc,
+	})
+}
+
+// ListTransactions returns the transactions matching filter in its sort
+// order, starting after filter.After when set. A zero Limit returns every
+// match.
+func (r *cashRepository) ListTransactions(filter domain.TransactionFilter) ([]domain.CashTransaction, error) {
 	var transactions []domain.CashTransaction
-	err := r.db.Preload("Category").
-		Where("transaction_date BETWEEN ? AND ?", start, end).
-		Order("transaction_date asc").
+
+	column, direction := transactionOrder(filter.Sort)
+	query := filterTransactions(r.db.Model(&domain.CashTransaction{}), filter)
+	if filter.After != nil {
+		var value any = filter.After.Date
+		if column == "amount" {
+			value = filter.After.Amount
+		}
+		operator := ">"
+		if direction == "desc" {
+			operator = "<"
+		}
+		query = query.Where(
+			"("+column+" "+operator+" ?) OR ("+column+" = ? AND id "+operator+" ?)",
+			value, value, filter.After.ID,
+		)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	err := query.Preload("Category").Preload("Account").
+		Order(column + " " + direction).
+		Order("id " + direction).
 		Find(&transactions).Error
 	return transactions, err
 }
 
-func (r *cashRepository) GetBalanceByDate(date time.Time) (*domain.CashBalance, error) {
+// transactionOrder maps a listing sort onto the column and direction to
+// order by; ties are always broken by id in the same direction.
+func transactionOrder(sort string) (column, direction string) {
+	column, direction = "transaction_date", "asc"
+	switch sort {
+	case domain.SortDateDesc:
+		direction = "desc"
+	case domain.SortAmountAsc:
+		column = "amount"
+	case domain.SortAmountDesc:
+		column, direction = "amount", "desc"
+	}
+	return column, direction
+}
+
+// SummarizeTransactions counts every transaction matching filter, ignoring
+// the cursor and limit. The in/out totals only cover regular transactions
+// unless filter.Kind asks for another kind, so transfers do not show up as
+// income or expense.
+func (r *cashRepository) SummarizeTransactions(filter domain.TransactionFilter) (domain.TransactionSummary, error) {
+	var summary domain.TransactionSummary
+
+	kind := filter.Kind
+	if kind == "" {
+		kind = domain.TransactionKindRegular
+	}
+
+	query := filterTransactions(r.db.Model(&domain.CashTransaction{}), filter)
+	err := query.Select(
+		"COUNT(*) AS count, "+
+			"COALESCE(SUM(CASE WHEN type = 'in' AND kind = ? THEN amount ELSE 0 END), 0) AS total_in, "+
+			"COALESCE(SUM(CASE WHEN type = 'out' AND kind = ? THEN amount ELSE 0 END), 0) AS total_out",
+		kind, kind,
+	).Scan(&summary).Error
+	summary.Net = summary.TotalIn - summary.TotalOut
+	return summary, err
+}
+
+func filterTransactions(query *gorm.DB, filter domain.TransactionFilter) *gorm.DB {
+	if filter.AccountID != 0 {
+		query = query.Where("account_id = ?", filter.AccountID)
+	}
+	if !filter.Start.IsZero() {
+		query = query.Where("transaction_date >= ?", filter.Start)
+	}
+	if !filter.End.IsZero() {
+		query = query.Where("transaction_date < ?", filter.End)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where("category_id = ?", filter.CategoryID)
+	}
+	if filter.PaymentMethod != "" {
+		query = query.Where("payment_method = ?", filter.PaymentMethod)
+	}
+	if filter.CreatedBy != 0 {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.Search != "" {
+		query = query.Where("description ILIKE ?", "%"+escapeLike(filter.Search)+"%")
+	}
+	return query
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// SumTransactionsByKind totals the in and out amounts of the given kind in
+// [start, end); accountID 0 means every account.
+func (r *cashRepository) SumTransactionsByKind(accountID uint, kind string, start, end time.Time) (domain.Money, domain.Money, error) {
+	var totals struct {
+		TotalIn  domain.Money
+		TotalOut domain.Money
+	}
+	query := r.db.Model(&domain.CashTransaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = 'in' THEN amount ELSE 0 END), 0) AS total_in, "+
+			"COALESCE(SUM(CASE WHEN type = 'out' THEN amount ELSE 0 END), 0) AS total_out").
+		Where("kind = ?", kind).
+		Where("transaction_date >= ? AND transaction_date < ?", start, end)
+	if accountID != 0 {
+		query = query.Where("account_id = ?", accountID)
+	}
+	err := query.Scan(&totals).Error
+	return totals.TotalIn, totals.TotalOut, err
+}
+
+func (r *cashRepository) GetBalanceByDate(accountID uint, date time.Time) (*domain.CashBalance, error) {
 	var balance domain.CashBalance
-	err := r.db.Where("date = ?", date.Format("2006-01-02")).First(&balance).Error
+	err := r.db.Where("account_id = ? AND date = ?", accountID, date.Format("2006-01-02")).First(&balance).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
 	return &balance, err
 }
 
+func (r *cashRepository) GetLastBalanceBefore(accountID uint, date time.Time) (*domain.CashBalance, error) {
+	var balance domain.CashBalance
+	err := r.db.Where("account_id = ? AND date < ?", accountID, date.Format("2006-01-02")).
+		Order("date desc").
+		First(&balance).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &balance, err
+}
+
+func (r *cashRepository) GetBalancesFrom(accountID uint, date time.Time) ([]domain.CashBalance, error) {
+	var balances []domain.CashBalance
+	err := r.db.Where("account_id = ? AND date >= ?", accountID, date.Format("2006-01-02")).
+		Order("date asc").
+		Find(&balances).Error
+	return balances, err
+}
+
 func (r *cashRepository) SaveOrUpdateBalance(balance *domain.CashBalance) error {
 	var existing domain.CashBalance
-	err := r.db.Where("date = ?", balance.Date.Format("2006-01-02")).First(&existing).Error
+	err := r.db.Where("account_id = ? AND date = ?", balance.AccountID, balance.Date.Format("2006-01-02")).
+		First(&existing).Error
 	if err == gorm.ErrRecordNotFound {
 		return r.db.Create(balance).Error
 	}
@@ -55,8 +284,137 @@ func (r *cashRepository) SaveOrUpdateBalance(balance *domain.CashBalance) error
 	return r.db.Save(balance).Error
 }
 
-func (r *cashRepository) GetAllCategories() ([]domain.CashCategory, error) {
+// LockBalances blocks until no other database transaction is changing the
+// account's balances; the lock is released when the surrounding transaction
+// ends, so it must be called inside UnitOfWork.Do.
+func (r *cashRepository) LockBalances(accountID uint) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(?, ?)", balanceLockKey, int32(accountID)).Error
+}
+
+// ApplyBalanceDelta adds the given movement to the totals of the account's
+// balance on date and shifts the opening and closing balance of every later
+// day by the net amount, so a correction in the past carries forward
+// through the book.
+func (r *cashRepository) ApplyBalanceDelta(accountID uint, date time.Time, totalIn, totalOut domain.Money) error {
+	day := date.Format("2006-01-02")
+	net := totalIn - totalOut
+	now := time.Now()
+
+	balance, err := r.GetBalanceByDate(accountID, date)
+	if err != nil {
+		return err
+	}
+	if balance == nil {
+		opening, err := r.GetOpeningBalance(accountID, date)
+		if err != nil {
+			return err
+		}
+		balance = &domain.CashBalance{
+			AccountID:      accountID,
+			Date:           date,
+			OpeningBalance: opening,
+			ClosingBalance: opening,
+			CalculatedAt:   now,
+		}
+		err = r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(balance).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.db.Model(&domain.CashBalance{}).
+		Where("account_id = ? AND date = ?", accountID, day).
+		Updates(map[string]any{
+			"total_in":        gorm.Expr("total_in + ?", totalIn),
+			"total_out":       gorm.Expr("total_out + ?", totalOut),
+			"closing_balance": gorm.Expr("closing_balance + ?", net),
+			"calculated_at":   now,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	return r.shiftBalances(accountID, "date > ?", day, net)
+}
+
+func (r *cashRepository) ShiftBalancesFrom(accountID uint, date time.Time, amount domain.Money) error {
+	return r.shiftBalances(accountID, "date >= ?", date.Format("2006-01-02"), amount)
+}
+
+func (r *cashRepository) shiftBalances(accountID uint, query string, day string, amount domain.Money) error {
+	return r.db.Model(&domain.CashBalance{}).
+		Where("account_id = ?", accountID).
+		Where(query, day).
+		Updates(map[string]any{
+			"opening_balance": gorm.Expr("opening_balance + ?", amount),
+			"closing_balance": gorm.Expr("closing_balance + ?", amount),
+			"calculated_at":   time.Now(),
+		}).Error
+}
+
+// GetOpeningBalance carries the closing balance of the account's closest
+// earlier day forward, falling back to the account's initial opening
+// balance.
+func (r *cashRepository) GetOpeningBalance(accountID uint, date time.Time) (domain.Money, error) {
+	previous, err := r.GetLastBalanceBefore(accountID, date)
+	if err != nil {
+		return 0, err
+	}
+	if previous != nil {
+		return previous.ClosingBalance, nil
+	}
+
+	account, err := r.GetAccountByID(accountID)
+	if err != nil || account == nil {
+		return 0, err
+	}
+	return account.OpeningBalance, nil
+}
+
+func (r *cashRepository) GetAllCategories(includeArchived bool) ([]domain.CashCategory, error) {
 	var cats []domain.CashCategory
-	err := r.db.Order("name asc").Find(&cats).Error
+	query := r.db.Order("name asc")
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	err := query.Find(&cats).Error
 	return cats, err
 }
+
+func (r *cashRepository) GetCategoryByID(id uint) (*domain.CashCategory, error) {
+	var category domain.CashCategory
+	err := r.db.First(&category, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &category, err
+}
+
+func (r *cashRepository) GetSystemCategory(key string) (*domain.CashCategory, error) {
+	var category domain.CashCategory
+	err := r.db.Where("system_key = ?", key).First(&category).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &category, err
+}
+
+func (r *cashRepository) CreateCategory(category *domain.CashCategory) error {
+	return r.db.Create(category).Error
+}
+
+func (r *cashRepository) UpdateCategory(category *domain.CashCategory) error {
+	return r.db.Omit("Transactions").Save(category).Error
+}
+
+// CountCategoryTransactions counts the transactions of the given type booked
+// under a category; an empty type counts all of them.
+func (r *cashRepository) CountCategoryTransactions(categoryID uint, transactionType string) (int64, error) {
+	var count int64
+	query := r.db.Model(&domain.CashTransaction{}).Where("category_id = ?", categoryID)
+	if transactionType != "" {
+		query = query.Where("type = ?", transactionType)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+)
+
+// GetBankReferences returns which of the given bank references are already
+// stored on transactions of the account.
+func (r *cashRepository) GetBankReferences(accountID uint, references []string) ([]string, error) {
+	const chunkSize = 1000
+
+	var existing []string
+	for start := 0; start < len(references); start += chunkSize {
+		end := min(start+chunkSize, len(references))
+
+		var chunk []string
+		err := r.db.Model(&domain.CashTransaction{}).
+			Where("account_id = ? AND bank_reference IN ?", accountID, references[start:end]).
+			Pluck("bank_reference", &chunk).Error
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, chunk...)
+	}
+	return existing, nil
+}
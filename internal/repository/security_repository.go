@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SecurityRepository interface {
+	GetLoginThrottle(scope, key string) (*domain.LoginThrottle, error)
+	RecordLoginFailure(scope, key string, now, windowStart time.Time) (domain.LoginThrottle, error)
+	LockLogins(scope, key string, until time.Time) error
+	ResetLoginThrottle(scope, key string) error
+	CreateSecurityEvent(event *domain.SecurityEvent) error
+	GetSecurityEvents(filter domain.SecurityEventFilter) ([]domain.SecurityEvent, error)
+}
+
+type securityRepository struct {
+	db *gorm.DB
+}
+
+func NewSecurityRepository(db *gorm.DB) SecurityRepository {
+	return &securityRepository{db: db}
+}
+
+func (r *securityRepository) GetLoginThrottle(scope, key string) (*domain.LoginThrottle, error) {
+	var throttle domain.LoginThrottle
+	err := r.db.Where("scope = ? AND throttle_key = ?", scope, key).First(&throttle).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &throttle, err
+}
+
+// RecordLoginFailure counts a failed login in one statement, so concurrent
+// failures are all counted. A failure after a quiet spell since
+// windowStart starts counting from one again.
+func (r *securityRepository) RecordLoginFailure(scope, key string, now, windowStart time.Time) (domain.LoginThrottle, error) {
+	throttle := domain.LoginThrottle{Scope: scope, Key: key, Failures: 1, LastFailureAt: now}
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "throttle_key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures":        gorm.Expr("CASE WHEN login_throttles.last_failure_at < ? THEN 1 ELSE login_throttles.failures + 1 END", windowStart),
+				"last_failure_at": now,
+			}),
+		},
+		clause.Returning{},
+	).Create(&throttle).Error
+	return throttle, err
+}
+
+func (r *securityRepository) LockLogins(scope, key string, until time.Time) error {
+	return r.db.Model(&domain.LoginThrottle{}).
+		Where("scope = ? AND throttle_key = ?", scope, key).
+		Update("locked_until", until).Error
+}
+
+func (r *securityRepository) ResetLoginThrottle(scope, key string) error {
+	return r.db.Where("scope = ? AND throttle_key = ?", scope, key).Delete(&domain.LoginThrottle{}).Error
+}
+
+func (r *securityRepository) CreateSecurityEvent(event *domain.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *securityRepository) GetSecurityEvents(filter domain.SecurityEventFilter) ([]domain.SecurityEvent, error) {
+	query := r.db.Model(&domain.SecurityEvent{})
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) = ?", strings.ToLower(filter.Email))
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	if filter.BeforeID != 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var events []domain.SecurityEvent
+	err := query.Order("id desc").Limit(filter.Limit).Find(&events).Error
+	return events, err
+}
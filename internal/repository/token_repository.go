@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRepository interface {
+	CreateRefreshToken(token *domain.RefreshToken) error
+	GetRefreshTokenByHash(hash string) (*domain.RefreshToken, error)
+	RevokeRefreshToken(id uint) (bool, error)
+	RevokeTokenFamily(familyID string) error
+	RevokeUserRefreshTokens(userID uint) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(jti string) (bool, error)
+	DeleteExpiredTokens(now time.Time) error
+	CreatePasswordReset(token *domain.PasswordResetToken) error
+	GetPasswordResetByHash(hash string) (*domain.PasswordResetToken, error)
+	UsePasswordReset(id uint) (bool, error)
+	InvalidatePasswordResets(userID uint) error
+	CreateLoginChallenge(challenge *domain.LoginChallenge) error
+	GetLoginChallengeByHash(hash string) (*domain.LoginChallenge, error)
+	FailLoginChallenge(id uint, maxAttempts int) error
+	UseLoginChallenge(id uint) (bool, error)
+}
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+func (r *tokenRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *tokenRepository) GetRefreshTokenByHash(hash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &token, err
+}
+
+// RevokeRefreshToken revokes the token unless it already was, and reports
+// whether this call revoked it. Of two requests refreshing with the same
+// token only one wins.
+func (r *tokenRepository) RevokeRefreshToken(id uint) (bool, error) {
+	result := r.db.Model(&domain.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *tokenRepository) RevokeTokenFamily(familyID string) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *tokenRepository) RevokeUserRefreshTokens(userID uint) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *tokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+}
+
+func (r *tokenRepository) IsAccessTokenRevoked(jti string) (bool, error) {
+	var total int64
+	err := r.db.Model(&domain.RevokedToken{}).Where("jti = ?", jti).Count(&total).Error
+	return total > 0, err
+}
+
+// DeleteExpiredTokens drops denylist entries, refresh tokens, reset tokens
+// and login challenges that have expired, as none of them can be used any
+// more.
+func (r *tokenRepository) DeleteExpiredTokens(now time.Time) error {
+	if err := r.db.Where("expires_at < ?", now).Delete(&domain.RevokedToken{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("expires_at < ?", now).Delete(&domain.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("expires_at < ?", now).Delete(&domain.PasswordResetToken{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("expires_at < ?", now).Delete(&domain.LoginChallenge{}).Error
+}
+
+func (r *tokenRepository) CreatePasswordReset(token *domain.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *tokenRepository) GetPasswordResetByHash(hash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &token, err
+}
+
+// UsePasswordReset marks the token used unless it already was, and reports
+// whether this call used it.
+func (r *tokenRepository) UsePasswordReset(id uint) (bool, error) {
+	result := r.db.Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// InvalidatePasswordResets marks every unused reset token of the user as
+// used, so only the latest emailed link works.
+func (r *tokenRepository) InvalidatePasswordResets(userID uint) error {
+	return r.db.Model(&domain.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+func (r *tokenRepository) CreateLoginChallenge(challenge *domain.LoginChallenge) error {
+	return r.db.Create(challenge).Error
+}
+
+func (r *tokenRepository) GetLoginChallengeByHash(hash string) (*domain.LoginChallenge, error) {
+	var challenge domain.LoginChallenge
+	err := r.db.Where("token_hash = ?", hash).First(&challenge).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &challenge, err
+}
+
+// FailLoginChallenge counts a wrong code; the attempt that reaches
+// maxAttempts uses the challenge up, so the password has to be entered
+// again before more codes can be guessed.
+func (r *tokenRepository) FailLoginChallenge(id uint, maxAttempts int) error {
+	err := r.db.Model(&domain.LoginChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&domain.LoginChallenge{}).
+		Where("id = ? AND used_at IS NULL AND attempts >= ?", id, maxAttempts).
+		Update("used_at", time.Now()).Error
+}
+
+// UseLoginChallenge marks the challenge used unless it already was, and
+// reports whether this call used it.
+func (r *tokenRepository) UseLoginChallenge(id uint) (bool, error) {
+	result := r.db.Model(&domain.LoginChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
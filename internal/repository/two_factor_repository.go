@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"go-project/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepository interface {
+	GetTwoFactor(userID uint) (*domain.TwoFactor, error)
+	SaveTwoFactorSecret(userID uint, secret string) error
+	EnableTwoFactor(userID uint, step int64, codeHashes []string) (bool, error)
+	DisableTwoFactor(userID uint) error
+	UseTOTPStep(userID uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+	CountRecoveryCodes(userID uint) (int64, error)
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) GetTwoFactor(userID uint) (*domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+	err := r.db.Where("user_id = ?", userID).First(&twoFactor).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &twoFactor, err
+}
+
+// SaveTwoFactorSecret stores a new, unconfirmed secret for the user,
+// replacing an earlier enrollment that was never confirmed.
+func (r *twoFactorRepository) SaveTwoFactorSecret(userID uint, secret string) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"secret": secret, "last_used_step": 0, "updated_at": time.Now()}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "two_factors.confirmed_at IS NULL"}}},
+	}).Create(&domain.TwoFactor{UserID: userID, Secret: secret}).Error
+}
+
+// EnableTwoFactor confirms the secret with the code of the given step and
+// stores the first recovery codes. It reports false when the secret was
+// confirmed already or the step was used before.
+func (r *twoFactorRepository) EnableTwoFactor(userID uint, step int64, codeHashes []string) (bool, error) {
+	enabled := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.TwoFactor{}).
+			Where("user_id = ? AND confirmed_at IS NULL AND last_used_step < ?", userID, step).
+			Updates(map[string]interface{}{"confirmed_at": time.Now(), "last_used_step": step})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		enabled = true
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	return enabled, err
+}
+
+func (r *twoFactorRepository) DisableTwoFactor(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&domain.TwoFactor{}).Error
+	})
+}
+
+// UseTOTPStep records that a code of the given time step was accepted, and
+// reports false when that step or a later one was used already.
+func (r *twoFactorRepository) UseTOTPStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&domain.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]domain.RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = domain.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return tx.Create(&codes).Error
+}
+
+// UseRecoveryCode marks the code used unless it already was, and reports
+// whether this call used it.
+func (r *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *twoFactorRepository) CountRecoveryCodes(userID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&domain.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&total).Error
+	return total, err
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Repositories groups the repositories bound to a single database
+// transaction inside UnitOfWork.Do.
+type Repositories struct {
+	Cash CashRepository
+	User UserRepository
+}
+
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn inside one database transaction; it commits when fn returns
+// nil and rolls back on an error or panic.
+func (u *unitOfWork) Do(fn func(repos Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Cash: NewCashRepository(tx),
+			User: NewUserRepository(tx),
+		})
+	})
+}
@@ -2,10 +2,15 @@ package repository
 
 import (
 	"go-project/internal/domain"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// usersLockKey identifies the advisory lock that serialises registrations,
+// so the first-user check and the insert cannot interleave.
+const usersLockKey = 7_301_002
+
 type UserRepository interface {
 	GetUsers() ([]domain.User, error)
 	GetUserByID(id uint) (domain.User, error)
@@ -13,6 +18,13 @@ type UserRepository interface {
 	UpdateUser(user domain.User) (domain.User, error)
 	DeleteUser(id uint) error
 	GetUserByEmail(email string) (domain.User, error)
+	CountUsers() (int64, error)
+	CountUsersByRole(role string) (int64, error)
+	MarkEmailVerified(id uint, at time.Time) error
+	ClaimVerificationSend(id uint, at, sentBefore time.Time) (bool, error)
+	ClaimPasswordResetSend(id uint, at, sentBefore time.Time) (bool, error)
+	RevokeUserTokens(id uint, at time.Time) error
+	LockUsers() error
 }
 
 type userRepository struct {
@@ -54,3 +66,53 @@ func (r *userRepository) GetUserByEmail(email string) (domain.User, error) {
 	err := r.db.Where("email = ?", email).First(&user).Error
 	return user, err
 }
+
+func (r *userRepository) CountUsers() (int64, error) {
+	var total int64
+	err := r.db.Model(&domain.User{}).Count(&total).Error
+	return total, err
+}
+
+func (r *userRepository) CountUsersByRole(role string) (int64, error) {
+	var total int64
+	err := r.db.Model(&domain.User{}).Where("role = ?", role).Count(&total).Error
+	return total, err
+}
+
+func (r *userRepository) MarkEmailVerified(id uint, at time.Time) error {
+	return r.db.Model(&domain.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", at).Error
+}
+
+// ClaimVerificationSend records a verification email sent at at, unless
+// one was already sent after sentBefore. It reports whether the send may
+// go ahead, so concurrent resend requests cannot both pass the throttle.
+func (r *userRepository) ClaimVerificationSend(id uint, at, sentBefore time.Time) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND (verification_sent_at IS NULL OR verification_sent_at <= ?)", id, sentBefore).
+		Update("verification_sent_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClaimPasswordResetSend is ClaimVerificationSend for password reset
+// emails.
+func (r *userRepository) ClaimPasswordResetSend(id uint, at, sentBefore time.Time) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND (password_reset_sent_at IS NULL OR password_reset_sent_at <= ?)", id, sentBefore).
+		Update("password_reset_sent_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeUserTokens invalidates every access token of the user issued before
+// at, which the auth middleware checks on each request.
+func (r *userRepository) RevokeUserTokens(id uint, at time.Time) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", id).Update("tokens_revoked_at", at).Error
+}
+
+// LockUsers blocks until no other database transaction is registering a
+// user; the lock is released when the surrounding transaction ends, so it
+// must be called inside UnitOfWork.Do.
+func (r *userRepository) LockUsers() error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(?)", usersLockKey).Error
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"strings"
+	"time"
+)
+
+func (u *cashUsecase) GetAccounts(includeArchived bool) ([]domain.CashAccount, error) {
+	return u.repo.GetAllAccounts(includeArchived)
+}
+
+func (u *cashUsecase) CreateAccount(account domain.CashAccount) (*domain.CashAccount, error) {
+	account.Name = strings.TrimSpace(account.Name)
+	if account.Name == "" {
+		return nil, ErrAccountNameRequired
+	}
+	if !domain.IsValidAccountType(account.Type) {
+		return nil, ErrInvalidAccountType
+	}
+	if account.OpeningBalance < 0 {
+		return nil, ErrNegativeOpeningBalance
+	}
+
+	account.ID = 0
+	account.ArchivedAt = nil
+	if err := u.repo.CreateAccount(&account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// UpdateAccount changes the descriptive fields of an account; the opening
+// balance goes through SetOpeningBalance so stored days are shifted too.
+func (u *cashUsecase) UpdateAccount(id uint, input domain.CashAccount) (*domain.CashAccount, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, ErrAccountNameRequired
+	}
+	if !domain.IsValidAccountType(input.Type) {
+		return nil, ErrInvalidAccountType
+	}
+
+	account, err := u.repo.GetAccountByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	account.Name = input.Name
+	account.Type = input.Type
+	account.Provider = input.Provider
+	account.AccountNumber = input.AccountNumber
+	if err := u.repo.UpdateAccount(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// ArchiveAccount stops an account from receiving new transactions while
+// keeping its history and balances.
+func (u *cashUsecase) ArchiveAccount(id uint) (*domain.CashAccount, error) {
+	account, err := u.repo.GetAccountByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if account.IsArchived() {
+		return account, nil
+	}
+
+	now := time.Now()
+	account.ArchivedAt = &now
+	if err := u.repo.UpdateAccount(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func checkAccountExists(repo repository.CashRepository, accountID uint) error {
+	account, err := repo.GetAccountByID(accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrAccountNotFound
+	}
+	return nil
+}
+
+// checkAccount verifies a transaction may be booked into accountID. An
+// archived account is only allowed when it is the one the transaction
+// already uses.
+func checkAccount(repo repository.CashRepository, accountID uint, current uint) error {
+	account, err := repo.GetAccountByID(accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrInvalidAccount
+	}
+	if account.IsArchived() && account.ID != current {
+		return ErrAccountArchived
+	}
+	return nil
+}
+
+var (
+	ErrAccountNotFound     = fmt.Errorf("akun kas tidak ditemukan")
+	ErrAccountNameRequired = fmt.Errorf("nama akun kas wajib diisi")
+	ErrInvalidAccountType  = fmt.Errorf("jenis akun kas tidak valid: harus 'cash', 'bank' atau 'ewallet'")
+	ErrInvalidAccount      = fmt.Errorf("akun kas transaksi tidak ditemukan")
+	ErrAccountArchived     = fmt.Errorf("akun kas sudah diarsipkan")
+)
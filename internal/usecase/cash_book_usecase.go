@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"go-project/internal/domain"
+	"time"
+)
+
+// GetCashBook returns the frame of the cash book between the start and end
+// days (inclusive): the account and the opening and closing balance of the
+// whole period and of each calendar month in it.
+func (u *cashUsecase) GetCashBook(accountID uint, start, end time.Time) (*domain.CashBook, error) {
+	start, end, err := u.reportPeriod(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	book := &domain.CashBook{AccountID: accountID, Start: start, End: end}
+	if accountID != 0 {
+		account, err := u.repo.GetAccountByID(accountID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrAccountNotFound
+		}
+		book.Account = account
+	}
+
+	for month := start; !month.After(end); month = truncatePeriod(month, domain.GroupByMonth).AddDate(0, 1, 0) {
+		monthEnd := truncatePeriod(month, domain.GroupByMonth).AddDate(0, 1, -1)
+		if monthEnd.After(end) {
+			monthEnd = end
+		}
+
+		opening, err := u.openingBalance(accountID, month)
+		if err != nil {
+			return nil, err
+		}
+		closing, err := u.openingBalance(accountID, monthEnd.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+		book.Months = append(book.Months, domain.CashBookMonth{
+			Start:          month,
+			End:            monthEnd,
+			OpeningBalance: opening,
+			ClosingBalance: closing,
+		})
+	}
+
+	book.OpeningBalance = book.Months[0].OpeningBalance
+	book.ClosingBalance = book.Months[len(book.Months)-1].ClosingBalance
+	return book, nil
+}
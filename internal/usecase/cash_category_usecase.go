@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"strings"
+	"time"
+)
+
+func (u *cashUsecase) GetCategories(includeArchived bool) ([]domain.CashCategory, error) {
+	return u.repo.GetAllCategories(includeArchived)
+}
+
+func (u *cashUsecase) CreateCategory(category domain.CashCategory) (*domain.CashCategory, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return nil, ErrCategoryNameRequired
+	}
+	if !domain.IsValidCategoryType(category.Type) {
+		return nil, ErrInvalidCategoryType
+	}
+
+	category.ID = 0
+	category.ArchivedAt = nil
+	category.SystemKey = nil
+	if err := u.repo.CreateCategory(&category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (u *cashUsecase) UpdateCategory(id uint, input domain.CashCategory) (*domain.CashCategory, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, ErrCategoryNameRequired
+	}
+	if !domain.IsValidCategoryType(input.Type) {
+		return nil, ErrInvalidCategoryType
+	}
+
+	category, err := u.repo.GetCategoryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	if category.IsSystem() && input.Type != category.Type {
+		return nil, ErrSystemCategoryType
+	}
+
+	// Narrowing the type must not strand transactions of the other kind.
+	if input.Type != "both" && input.Type != category.Type {
+		opposite := "in"
+		if input.Type == "in" {
+			opposite = "out"
+		}
+		count, err := u.repo.CountCategoryTransactions(id, opposite)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, ErrCategoryTypeInUse
+		}
+	}
+
+	category.Name = input.Name
+	category.Type = input.Type
+	category.Description = input.Description
+	if err := u.repo.UpdateCategory(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// ArchiveCategory hides a category from new transactions while keeping it
+// attached to the ones already booked under it. System categories cannot
+// be archived, as the application keeps booking under them.
+func (u *cashUsecase) ArchiveCategory(id uint) (*domain.CashCategory, error) {
+	category, err := u.repo.GetCategoryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+	if category.IsSystem() {
+		return nil, ErrSystemCategoryArchive
+	}
+	if category.IsArchived() {
+		return category, nil
+	}
+
+	now := time.Now()
+	category.ArchivedAt = &now
+	if err := u.repo.UpdateCategory(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// checkCategory verifies a transaction of the given type may be booked
+// under categoryID. System categories are never allowed; an archived
+// category only when it is the one the transaction already uses.
+func checkCategory(repo repository.CashRepository, categoryID uint, transactionType string, current uint) error {
+	category, err := repo.GetCategoryByID(categoryID)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return ErrInvalidCategory
+	}
+	if category.IsSystem() {
+		return ErrSystemCategory
+	}
+	if category.IsArchived() && category.ID != current {
+		return ErrCategoryArchived
+	}
+	if !category.Accepts(transactionType) {
+		return ErrCategoryTypeMismatch
+	}
+	return nil
+}
+
+var (
+	ErrCategoryNotFound      = fmt.Errorf("kategori tidak ditemukan")
+	ErrCategoryNameRequired  = fmt.Errorf("nama kategori wajib diisi")
+	ErrInvalidCategoryType   = fmt.Errorf("jenis kategori tidak valid: harus 'in', 'out' atau 'both'")
+	ErrCategoryTypeInUse     = fmt.Errorf("jenis kategori tidak dapat diubah karena masih dipakai transaksi jenis lain")
+	ErrSystemCategory        = fmt.Errorf("kategori sistem hanya dipakai untuk pencatatan otomatis dan tidak dapat dipilih")
+	ErrSystemCategoryType    = fmt.Errorf("jenis kategori sistem tidak dapat diubah")
+	ErrSystemCategoryArchive = fmt.Errorf("kategori sistem tidak dapat diarsipkan")
+)
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeCategoryRepository keeps categories in memory by ID.
+type fakeCategoryRepository struct {
+	repository.CashRepository
+	categories map[uint]*domain.CashCategory
+}
+
+func (r *fakeCategoryRepository) GetCategoryByID(id uint) (*domain.CashCategory, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *category
+	return &copied, nil
+}
+
+func (r *fakeCategoryRepository) GetSystemCategory(key string) (*domain.CashCategory, error) {
+	for id, category := range r.categories {
+		if category.SystemKey != nil && *category.SystemKey == key {
+			return r.GetCategoryByID(id)
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeCategoryRepository) UpdateCategory(category *domain.CashCategory) error {
+	copied := *category
+	r.categories[category.ID] = &copied
+	return nil
+}
+
+func TestArchiveSystemCategory(t *testing.T) {
+	key := domain.SystemCategoryTransfer
+	repo := &fakeCategoryRepository{categories: map[uint]*domain.CashCategory{
+		1: {ID: 1, Name: "Transfer", Type: "both", SystemKey: &key},
+		2: {ID: 2, Name: "ATK", Type: "out"},
+	}}
+	u := &cashUsecase{repo: repo}
+
+	if _, err := u.ArchiveCategory(1); err != ErrSystemCategoryArchive {
+		t.Errorf("archiving a system category: error = %v, want %v", err, ErrSystemCategoryArchive)
+	}
+	if repo.categories[1].IsArchived() {
+		t.Error("system category archived")
+	}
+	if category, err := u.ArchiveCategory(2); err != nil || !category.IsArchived() {
+		t.Errorf("archiving a regular category: %+v, %v", category, err)
+	}
+
+	// A system category archived before that was refused is used again.
+	archived := time.Now()
+	repo.categories[1].ArchivedAt = &archived
+	category, err := systemCategory(repo, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.ID != 1 || category.IsArchived() || repo.categories[1].IsArchived() {
+		t.Errorf("system category = %+v, want category 1 no longer archived", category)
+	}
+}
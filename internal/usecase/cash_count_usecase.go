@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"time"
+)
+
+// maxCountQuantity is the most pieces of one denomination a count may list,
+// far above any drawer, so a typo cannot book an absurd difference. It also
+// keeps the counted total below 2e13 sen, well within numeric(15,2).
+const maxCountQuantity = 1_000_000
+
+// CountCash records a draft count of a cash account's drawer at the end of
+// count.Date and compares the counted total with the book's closing
+// balance for that day. Nothing is booked until the count is confirmed.
+func (u *cashUsecase) CountCash(count domain.CashCount) (*domain.CashCount, error) {
+	if count.Date.IsZero() {
+		count.Date = time.Now()
+	}
+	if u.isFutureDate(count.Date) {
+		return nil, ErrFutureCountDate
+	}
+	count.Date = u.bookDay(count.Date)
+
+	items, total, err := countItems(count.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := activeAccount(u.repo, count.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Type != "cash" {
+		return nil, ErrCashCountNotCashAccount
+	}
+
+	expected, err := u.repo.GetOpeningBalance(count.AccountID, count.Date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	count.ID = 0
+	count.Items = items
+	count.CountedTotal = total
+	count.ExpectedBalance = expected
+	count.Difference = total - expected
+	count.Status = domain.CashCountDraft
+	count.AdjustmentID, count.ConfirmedBy, count.ConfirmedAt = nil, nil, nil
+	count.Account, count.Adjustment = nil, nil
+	if err := u.repo.CreateCashCount(&count); err != nil {
+		return nil, err
+	}
+	return u.repo.GetCashCountByID(count.ID)
+}
+
+// countItems checks the counted denominations and returns them largest
+// first with their subtotals, together with the counted total.
+func countItems(input []domain.CashCountItem) ([]domain.CashCountItem, domain.Money, error) {
+	if len(input) == 0 {
+		return nil, 0, ErrCashCountItemsRequired
+	}
+	quantities := make(map[domain.Money]int, len(input))
+	for _, item := range input {
+		if !isDenomination(item.Denomination) {
+			return nil, 0, fmt.Errorf("%w: %s", ErrInvalidDenomination, item.Denomination)
+		}
+		if item.Quantity < 0 || item.Quantity > maxCountQuantity {
+			return nil, 0, ErrInvalidQuantity
+		}
+		if _, ok := quantities[item.Denomination]; ok {
+			return nil, 0, fmt.Errorf("%w: %s", ErrDuplicateDenomination, item.Denomination)
+		}
+		quantities[item.Denomination] = item.Quantity
+	}
+
+	var items []domain.CashCountItem
+	var total domain.Money
+	for _, denomination := range domain.Denominations {
+		quantity, ok := quantities[denomination]
+		if !ok {
+			continue
+		}
+		subtotal := denomination * domain.Money(quantity)
+		items = append(items, domain.CashCountItem{
+			Denomination: denomination,
+			Quantity:     quantity,
+			Subtotal:     subtotal,
+		})
+		total += subtotal
+	}
+	return items, total, nil
+}
+
+func isDenomination(value domain.Money) bool {
+	for _, denomination := range domain.Denominations {
+		if value == denomination {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *cashUsecase) GetCashCounts(accountID uint, start, end time.Time) ([]domain.CashCount, error) {
+	return u.repo.GetCashCounts(accountID, start, end)
+}
+
+func (u *cashUsecase) GetCashCount(id uint) (*domain.CashCount, error) {
+	count, err := u.repo.GetCashCountByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, ErrCashCountNotFound
+	}
+	return count, nil
+}
+
+// ConfirmCashCount closes the counted day. The difference is recomputed
+// against the current book, since transactions may have been recorded
+// after the count was taken; a cash over is booked as money in and a cash
+// short as money out under the cash difference system category at the end of the
+// counted day, so the book's closing balance then equals the count.
+func (u *cashUsecase) ConfirmCashCount(id, userID uint) (*domain.CashCount, error) {
+	err := u.write(func(repo repository.CashRepository) error {
+		count, err := repo.GetCashCountForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if count == nil {
+			return ErrCashCountNotFound
+		}
+		if count.IsConfirmed() {
+			return ErrCashCountConfirmed
+		}
+		if err := lockAccounts(repo, count.AccountID); err != nil {
+			return err
+		}
+		if _, err := activeAccount(repo, count.AccountID); err != nil {
+			return err
+		}
+
+		date := u.calendarDay(count.Date)
+		if err := u.checkOpenPeriod(repo, date); err != nil {
+			return err
+		}
+		confirmed, err := repo.HasConfirmedCashCount(count.AccountID, date)
+		if err != nil {
+			return err
+		}
+		if confirmed {
+			return ErrCashCountDayClosed
+		}
+
+		expected, err := repo.GetOpeningBalance(count.AccountID, date.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		count.ExpectedBalance = expected
+		count.Difference = count.CountedTotal - expected
+
+		if !count.Difference.IsZero() {
+			category, err := systemCategory(repo, domain.SystemCategoryCashDifference)
+			if err != nil {
+				return err
+			}
+			adjustment := domain.CashTransaction{
+				TransactionDate: date.AddDate(0, 0, 1).Add(-time.Second),
+				AccountID:       count.AccountID,
+				Type:            "in",
+				Kind:            domain.TransactionKindRegular,
+				CategoryID:      category.ID,
+				Description:     fmt.Sprintf("Kelebihan kas hasil hitung fisik %s", date.Format("02-01-2006")),
+				Amount:          count.Difference.Abs(),
+				PaymentMethod:   "cash",
+				CreatedBy:       userID,
+			}
+			if count.Difference < 0 {
+				adjustment.Type = "out"
+				adjustment.Description = fmt.Sprintf("Kekurangan kas hasil hitung fisik %s", date.Format("02-01-2006"))
+			}
+			if err := repo.CreateTransaction(&adjustment); err != nil {
+				return err
+			}
+			if err := u.postBalance(repo, adjustment, 1); err != nil {
+				return err
+			}
+			count.AdjustmentID = &adjustment.ID
+		}
+
+		now := time.Now()
+		count.Status = domain.CashCountConfirmed
+		count.ConfirmedBy = &userID
+		count.ConfirmedAt = &now
+		return repo.UpdateCashCount(count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetCashCountByID(id)
+}
+
+var (
+	ErrCashCountNotFound       = fmt.Errorf("hitung kas tidak ditemukan")
+	ErrCashCountConfirmed      = fmt.Errorf("hitung kas sudah dikonfirmasi")
+	ErrCashCountDayClosed      = fmt.Errorf("hitung kas untuk akun dan tanggal ini sudah dikonfirmasi")
+	ErrCashCountNotCashAccount = fmt.Errorf("hitung kas fisik hanya untuk akun berjenis cash")
+	ErrFutureCountDate         = fmt.Errorf("tanggal hitung kas tidak boleh di masa depan")
+	ErrCashCountItemsRequired  = fmt.Errorf("isi jumlah minimal satu pecahan; laci kosong dicatat dengan jumlah 0")
+	ErrInvalidDenomination     = fmt.Errorf("pecahan tidak valid: harus salah satu pecahan rupiah 100.000 sampai 100")
+	ErrDuplicateDenomination   = fmt.Errorf("pecahan tercantum lebih dari sekali")
+	ErrInvalidQuantity         = fmt.Errorf("jumlah lembar atau keping harus antara 0 dan 1.000.000")
+)
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"go-project/internal/domain"
+)
+
+// ExportTransactions streams every transaction matching filter to fn, with
+// the same filters and day range as ListTransactions but without paging.
+// Category and Account are filled in from lookups loaded once up front so the
+// rows can be written out as they arrive.
+func (u *cashUsecase) ExportTransactions(filter domain.TransactionFilter, fn func(domain.CashTransaction) error) error {
+	if err := u.normalizeFilter(&filter); err != nil {
+		return err
+	}
+	filter.After = nil
+	filter.Limit = 0
+
+	categories, err := u.repo.GetAllCategories(true)
+	if err != nil {
+		return err
+	}
+	categoryByID := make(map[uint]*domain.CashCategory, len(categories))
+	for i := range categories {
+		categoryByID[categories[i].ID] = &categories[i]
+	}
+
+	accounts, err := u.repo.GetAllAccounts(true)
+	if err != nil {
+		return err
+	}
+	accountByID := make(map[uint]*domain.CashAccount, len(accounts))
+	for i := range accounts {
+		accountByID[accounts[i].ID] = &accounts[i]
+	}
+
+	return u.repo.StreamTransactions(filter, func(transaction domain.CashTransaction) error {
+		transaction.Category = categoryByID[transaction.CategoryID]
+		transaction.Account = accountByID[transaction.AccountID]
+		return fn(transaction)
+	})
+}
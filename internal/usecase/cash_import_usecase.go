@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportTransactions validates parsed import rows against the accounts and
+// categories of the book, resolving names or ids, and reports every row.
+// Unless dryRun is set, all valid rows are then stored in one database
+// transaction and the balances of each affected account are rebuilt once
+// from the earliest imported day. accountID is used for rows without an
+// account column.
+func (u *cashUsecase) ImportTransactions(rows []domain.ImportRow, accountID, userID uint, dryRun bool) (*domain.ImportResult, error) {
+	categories, err := u.repo.GetAllCategories(true)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := u.repo.GetAllAccounts(true)
+	if err != nil {
+		return nil, err
+	}
+	if accountID != 0 {
+		if err := checkAccountExists(u.repo, accountID); err != nil {
+			return nil, err
+		}
+	}
+	closed, err := u.repo.GetClosedPeriods()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{DryRun: dryRun, Total: len(rows)}
+	var valid []*domain.CashTransaction
+	for i := range rows {
+		row := &rows[i]
+		if row.Valid() {
+			u.resolveImportRow(row, accounts, categories, accountID, userID)
+		}
+		if row.Valid() {
+			if period := u.closedPeriodAt(closed, row.Transaction.TransactionDate); period != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s: %s", ErrPeriodClosed, u.periodRange(*period)))
+			}
+		}
+
+		rowResult := domain.ImportRowResult{Line: row.Line, Valid: row.Valid(), Errors: row.Errors}
+		if row.Valid() {
+			result.Valid++
+			rowResult.Transaction = &row.Transaction
+			valid = append(valid, &row.Transaction)
+		} else {
+			result.Invalid++
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+	if dryRun || len(valid) == 0 {
+		return result, nil
+	}
+
+	err = u.write(func(repo repository.CashRepository) error {
+		from := make(map[uint]time.Time)
+		for _, transaction := range valid {
+			day := u.bookDay(transaction.TransactionDate)
+			if earliest, ok := from[transaction.AccountID]; !ok || day.Before(earliest) {
+				from[transaction.AccountID] = day
+			}
+		}
+		accountIDs := make([]uint, 0, len(from))
+		for id := range from {
+			accountIDs = append(accountIDs, id)
+		}
+		if err := lockAccounts(repo, accountIDs...); err != nil {
+			return err
+		}
+		dates := make([]time.Time, 0, len(valid))
+		for _, transaction := range valid {
+			dates = append(dates, transaction.TransactionDate)
+		}
+		if err := u.checkOpenPeriod(repo, dates...); err != nil {
+			return err
+		}
+
+		// Re-check inside the transaction what the lookups above may have
+		// missed since, once per account and category.
+		for _, id := range accountIDs {
+			if err := checkAccount(repo, id, 0); err != nil {
+				return err
+			}
+		}
+		checked := make(map[string]bool)
+		for _, transaction := range valid {
+			key := fmt.Sprintf("%d/%s", transaction.CategoryID, transaction.Type)
+			if checked[key] {
+				continue
+			}
+			if err := checkCategory(repo, transaction.CategoryID, transaction.Type, 0); err != nil {
+				return err
+			}
+			checked[key] = true
+		}
+
+		for _, transaction := range valid {
+			if err := repo.CreateTransaction(transaction); err != nil {
+				return err
+			}
+		}
+		for _, id := range accountIDs {
+			if err := u.recalculateBalances(repo, id, from[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = len(valid)
+	return result, nil
+}
+
+// resolveImportRow fills in the account and category of a well-formed row
+// and applies the checks RecordTransaction makes, adding any failure to the
+// row's errors.
+func (u *cashUsecase) resolveImportRow(row *domain.ImportRow, accounts []domain.CashAccount, categories []domain.CashCategory, accountID, userID uint) {
+	transaction := &row.Transaction
+	fail := func(err error) {
+		row.Errors = append(row.Errors, err.Error())
+	}
+
+	if transaction.Type != "in" && transaction.Type != "out" {
+		fail(ErrInvalidTransactionType)
+	}
+	if !transaction.Amount.IsPositive() {
+		fail(ErrInvalidAmount)
+	}
+	if u.isFutureDate(transaction.TransactionDate) {
+		fail(ErrFutureTransactionDate)
+	}
+
+	switch {
+	case row.Account != "":
+		account := findAccount(accounts, row.Account)
+		if account == nil {
+			fail(fmt.Errorf("akun kas %q tidak ditemukan", row.Account))
+		} else if account.IsArchived() {
+			fail(ErrAccountArchived)
+		} else {
+			transaction.AccountID = account.ID
+		}
+	case accountID != 0:
+		transaction.AccountID = accountID
+	default:
+		fail(ErrImportAccountRequired)
+	}
+
+	category := findCategory(categories, row.Category)
+	switch {
+	case category == nil:
+		fail(fmt.Errorf("kategori %q tidak ditemukan", row.Category))
+	case category.IsArchived():
+		fail(ErrCategoryArchived)
+	case transaction.Type != "" && !category.Accepts(transaction.Type):
+		fail(ErrCategoryTypeMismatch)
+	default:
+		transaction.CategoryID = category.ID
+	}
+
+	transaction.Kind = domain.TransactionKindRegular
+	transaction.CreatedBy = userID
+	if transaction.PaymentMethod == "" {
+		transaction.PaymentMethod = "cash"
+	}
+}
+
+// findAccount looks an account up by id or, failing that, by name ignoring
+// case.
+func findAccount(accounts []domain.CashAccount, value string) *domain.CashAccount {
+	id, err := strconv.ParseUint(value, 10, 64)
+	for i := range accounts {
+		if err == nil && accounts[i].ID == uint(id) {
+			return &accounts[i]
+		}
+		if strings.EqualFold(accounts[i].Name, value) {
+			return &accounts[i]
+		}
+	}
+	return nil
+}
+
+// findCategory looks a category up by id or, failing that, by name ignoring
+// case.
+func findCategory(categories []domain.CashCategory, value string) *domain.CashCategory {
+	id, err := strconv.ParseUint(value, 10, 64)
+	for i := range categories {
+		if err == nil && categories[i].ID == uint(id) {
+			return &categories[i]
+		}
+		if strings.EqualFold(categories[i].Name, value) {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+var (
+	ErrImportAccountRequired = fmt.Errorf("akun kas wajib diisi: pilih account_id atau petakan kolom akun")
+)
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+)
+
+const (
+	defaultTransactionPageSize = 50
+	maxTransactionPageSize     = 200
+)
+
+// ListTransactions returns one page of transactions matching filter.
+// filter.Start and filter.End are days in the book's time zone, both
+// inclusive; cursor is the NextCursor of the previous page, listed with the
+// same sort.
+func (u *cashUsecase) ListTransactions(filter domain.TransactionFilter, cursor string) (*domain.TransactionPage, error) {
+	if err := u.normalizeFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	if cursor != "" {
+		after, err := domain.DecodeTransactionCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.Sort != filter.Sort {
+			return nil, domain.ErrCursorSortMismatch
+		}
+		filter.After = after
+	}
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultTransactionPageSize
+	case filter.Limit > maxTransactionPageSize:
+		filter.Limit = maxTransactionPageSize
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+	transactions, err := u.repo.ListTransactions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := u.repo.SummarizeTransactions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.TransactionPage{
+		Transactions: transactions,
+		Limit:        pageSize,
+		Summary:      summary,
+	}
+	if len(transactions) > pageSize {
+		page.Transactions = transactions[:pageSize]
+		page.HasMore = true
+		page.NextCursor = domain.NewTransactionCursor(transactions[pageSize-1], filter.Sort).Encode()
+	}
+	return page, nil
+}
+
+// normalizeFilter validates the enumerated fields of filter and turns its
+// inclusive day range into the [Start, End) instants the repository uses.
+func (u *cashUsecase) normalizeFilter(filter *domain.TransactionFilter) error {
+	if filter.Type != "" && filter.Type != "in" && filter.Type != "out" {
+		return ErrInvalidTransactionType
+	}
+	if filter.Kind != "" && filter.Kind != domain.TransactionKindRegular && filter.Kind != domain.TransactionKindTransfer {
+		return ErrInvalidTransactionKind
+	}
+	switch filter.Sort {
+	case "":
+		filter.Sort = domain.SortDateAsc
+	case domain.SortDateAsc, domain.SortDateDesc, domain.SortAmountAsc, domain.SortAmountDesc:
+	default:
+		return ErrInvalidSort
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return ErrInvalidAmountRange
+	}
+
+	if !filter.Start.IsZero() {
+		filter.Start = u.bookDay(filter.Start)
+	}
+	if !filter.End.IsZero() {
+		filter.End = u.bookDay(filter.End).AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+var (
+	ErrInvalidTransactionKind = fmt.Errorf("jenis pencatatan tidak valid: harus 'regular' atau 'transfer'")
+	ErrInvalidSort            = fmt.Errorf("urutan tidak valid: harus date_asc, date_desc, amount_asc atau amount_desc")
+	ErrInvalidAmountRange     = fmt.Errorf("nominal minimum tidak boleh lebih besar dari nominal maksimum")
+)
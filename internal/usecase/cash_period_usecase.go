@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"strings"
+	"time"
+)
+
+// ClosePeriod locks the days from start to end (inclusive) of every account
+// and snapshots each account's balances and the category totals of the
+// period. Every account is locked while the snapshot is taken, so no
+// transaction can slip into the period as it closes.
+func (u *cashUsecase) ClosePeriod(start, end time.Time, userID uint, note string) (*domain.PeriodClose, error) {
+	if start.IsZero() || end.IsZero() {
+		return nil, ErrPeriodRequired
+	}
+	start, end = u.bookDay(start), u.bookDay(end)
+	if start.After(end) {
+		return nil, ErrInvalidDateRange
+	}
+	if u.isFutureDate(end) {
+		return nil, ErrFuturePeriod
+	}
+
+	var period *domain.PeriodClose
+	err := u.write(func(repo repository.CashRepository) error {
+		accounts, err := repo.GetAllAccounts(true)
+		if err != nil {
+			return err
+		}
+		accountIDs := make([]uint, 0, len(accounts))
+		for _, account := range accounts {
+			accountIDs = append(accountIDs, account.ID)
+		}
+		if err := lockAccounts(repo, accountIDs...); err != nil {
+			return err
+		}
+
+		closed, err := repo.GetClosedPeriods()
+		if err != nil {
+			return err
+		}
+		for _, other := range closed {
+			if !start.After(u.calendarDay(other.PeriodEnd)) && !end.Before(u.calendarDay(other.PeriodStart)) {
+				return fmt.Errorf("%w: %s", ErrPeriodOverlap, u.periodRange(other))
+			}
+		}
+
+		period = &domain.PeriodClose{
+			PeriodStart: start,
+			PeriodEnd:   end,
+			Status:      domain.PeriodClosed,
+			Note:        strings.TrimSpace(note),
+			ClosedBy:    userID,
+			ClosedAt:    time.Now(),
+		}
+		if err := u.snapshotPeriod(repo, period, accounts); err != nil {
+			return err
+		}
+		if err := repo.CreatePeriodClose(period); err != nil {
+			return err
+		}
+		return repo.CreatePeriodEvent(&domain.PeriodEvent{
+			PeriodCloseID: period.ID,
+			Action:        domain.PeriodEventClose,
+			UserID:        userID,
+			Reason:        period.Note,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetPeriodCloseByID(period.ID)
+}
+
+// snapshotPeriod fills in the balances of every account and the category
+// totals of the period.
+func (u *cashUsecase) snapshotPeriod(repo repository.CashRepository, period *domain.PeriodClose, accounts []domain.CashAccount) error {
+	categories, err := repo.GetAllCategories(true)
+	if err != nil {
+		return err
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	totals := make(map[uint]*domain.CategoryTotal)
+	for _, account := range accounts {
+		opening, err := repo.GetOpeningBalance(account.ID, period.PeriodStart)
+		if err != nil {
+			return err
+		}
+		rows, err := repo.AggregateTransactions(domain.TransactionFilter{
+			AccountID: account.ID,
+			Start:     period.PeriodStart,
+			End:       period.PeriodEnd.AddDate(0, 0, 1),
+		}, domain.GroupByYear, u.location)
+		if err != nil {
+			return err
+		}
+
+		balance := domain.PeriodBalanceSnapshot{
+			AccountID:      account.ID,
+			AccountName:    account.Name,
+			OpeningBalance: opening,
+		}
+		for _, row := range rows {
+			if row.Kind == domain.TransactionKindTransfer {
+				balance.TransferIn += row.TotalIn
+				balance.TransferOut += row.TotalOut
+				continue
+			}
+			balance.TotalIn += row.TotalIn
+			balance.TotalOut += row.TotalOut
+			addCategoryTotal(totals, categoryNames, row)
+		}
+		balance.ClosingBalance = opening + balance.TotalIn - balance.TotalOut + balance.TransferIn - balance.TransferOut
+		period.Balances = append(period.Balances, balance)
+	}
+
+	for _, total := range sortedCategoryTotals(totals) {
+		period.Categories = append(period.Categories, domain.PeriodCategorySnapshot{
+			CategoryID:   total.CategoryID,
+			CategoryName: total.CategoryName,
+			TotalIn:      total.TotalIn,
+			TotalOut:     total.TotalOut,
+			Net:          total.Net,
+		})
+	}
+	return nil
+}
+
+// ReopenPeriod unlocks a closed period again. The reason is required and is
+// logged with the user; the period's snapshot is kept as it was.
+func (u *cashUsecase) ReopenPeriod(id, userID uint, reason string) (*domain.PeriodClose, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReopenReasonRequired
+	}
+
+	err := u.write(func(repo repository.CashRepository) error {
+		period, err := repo.GetPeriodCloseForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if period == nil {
+			return ErrPeriodNotFound
+		}
+		if !period.IsClosed() {
+			return ErrPeriodNotClosed
+		}
+
+		now := time.Now()
+		period.Status = domain.PeriodReopened
+		period.ReopenedBy = &userID
+		period.ReopenedAt = &now
+		period.ReopenReason = reason
+		if err := repo.ReopenPeriod(period); err != nil {
+			return err
+		}
+		return repo.CreatePeriodEvent(&domain.PeriodEvent{
+			PeriodCloseID: period.ID,
+			Action:        domain.PeriodEventReopen,
+			UserID:        userID,
+			Reason:        reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetPeriodCloseByID(id)
+}
+
+func (u *cashUsecase) GetPeriodCloses() ([]domain.PeriodClose, error) {
+	return u.repo.GetPeriodCloses()
+}
+
+func (u *cashUsecase) GetPeriodClose(id uint) (*domain.PeriodClose, error) {
+	period, err := u.repo.GetPeriodCloseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, ErrPeriodNotFound
+	}
+	return period, nil
+}
+
+// checkOpenPeriod fails when any of the dates falls in a closed period.
+// Writers call it after lockAccounts, which ClosePeriod also takes.
+func (u *cashUsecase) checkOpenPeriod(repo repository.CashRepository, dates ...time.Time) error {
+	closed, err := repo.GetClosedPeriods()
+	if err != nil {
+		return err
+	}
+	for _, date := range dates {
+		if period := u.closedPeriodAt(closed, date); period != nil {
+			return fmt.Errorf("%w: %s", ErrPeriodClosed, u.periodRange(*period))
+		}
+	}
+	return nil
+}
+
+// dayRange is the book days from start up to, not including, end. A zero
+// end leaves the range open.
+type dayRange struct {
+	start, end time.Time
+}
+
+// openRanges splits the days from from onwards around the closed periods,
+// oldest first, so a rebuild can leave their balances as they were closed.
+func (u *cashUsecase) openRanges(closed []domain.PeriodClose, from time.Time) []dayRange {
+	var ranges []dayRange
+	for _, period := range closed {
+		start := u.calendarDay(period.PeriodStart)
+		next := u.calendarDay(period.PeriodEnd).AddDate(0, 0, 1)
+		if !from.Before(next) {
+			continue
+		}
+		if from.Before(start) {
+			ranges = append(ranges, dayRange{start: from, end: start})
+		}
+		from = next
+	}
+	return append(ranges, dayRange{start: from})
+}
+
+func (u *cashUsecase) closedPeriodAt(closed []domain.PeriodClose, date time.Time) *domain.PeriodClose {
+	day := u.bookDay(date)
+	for i := range closed {
+		if !day.Before(u.calendarDay(closed[i].PeriodStart)) && !day.After(u.calendarDay(closed[i].PeriodEnd)) {
+			return &closed[i]
+		}
+	}
+	return nil
+}
+
+func (u *cashUsecase) periodRange(period domain.PeriodClose) string {
+	return fmt.Sprintf("%s s.d. %s",
+		u.calendarDay(period.PeriodStart).Format("02-01-2006"),
+		u.calendarDay(period.PeriodEnd).Format("02-01-2006"))
+}
+
+var (
+	ErrPeriodNotFound       = fmt.Errorf("periode tutup buku tidak ditemukan")
+	ErrPeriodRequired       = fmt.Errorf("tanggal awal dan akhir periode wajib diisi")
+	ErrFuturePeriod         = fmt.Errorf("akhir periode tutup buku tidak boleh di masa depan")
+	ErrPeriodOverlap        = fmt.Errorf("periode bertumpang tindih dengan periode yang sudah ditutup")
+	ErrPeriodClosed         = fmt.Errorf("tanggal transaksi berada dalam periode yang sudah ditutup")
+	ErrPeriodNotClosed      = fmt.Errorf("periode tidak sedang ditutup")
+	ErrReopenReasonRequired = fmt.Errorf("alasan membuka kembali periode wajib diisi")
+	ErrOpeningBalanceLocked = fmt.Errorf("saldo awal tidak dapat diubah karena sudah ada periode yang ditutup")
+)
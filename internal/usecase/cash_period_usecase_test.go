@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fakeBalanceRepository keeps the daily balances, transactions and closed
+// periods of one account in memory, for the rebuild to run against.
+type fakeBalanceRepository struct {
+	repository.CashRepository
+	opening      domain.Money
+	closed       []domain.PeriodClose
+	balances     map[string]domain.CashBalance
+	transactions []domain.CashTransaction
+}
+
+func (r *fakeBalanceRepository) GetClosedPeriods() ([]domain.PeriodClose, error) {
+	return r.closed, nil
+}
+
+func (r *fakeBalanceRepository) days() []string {
+	keys := make([]string, 0, len(r.balances))
+	for key := range r.balances {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *fakeBalanceRepository) GetOpeningBalance(accountID uint, date time.Time) (domain.Money, error) {
+	opening := r.opening
+	for _, key := range r.days() {
+		if key < date.Format("2006-01-02") {
+			opening = r.balances[key].ClosingBalance
+		}
+	}
+	return opening, nil
+}
+
+func (r *fakeBalanceRepository) GetBalancesFrom(accountID uint, date time.Time) ([]domain.CashBalance, error) {
+	var balances []domain.CashBalance
+	for _, key := range r.days() {
+		if key >= date.Format("2006-01-02") {
+			balances = append(balances, r.balances[key])
+		}
+	}
+	return balances, nil
+}
+
+func (r *fakeBalanceRepository) GetTransactions(accountID uint, start, end time.Time) ([]domain.CashTransaction, error) {
+	var transactions []domain.CashTransaction
+	for _, transaction := range r.transactions {
+		if !transaction.TransactionDate.Before(start) && transaction.TransactionDate.Before(end) {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+func (r *fakeBalanceRepository) SaveOrUpdateBalance(balance *domain.CashBalance) error {
+	r.balances[balance.Date.Format("2006-01-02")] = *balance
+	return nil
+}
+
+func TestOpenRanges(t *testing.T) {
+	u := &cashUsecase{location: time.UTC}
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC) }
+	closed := []domain.PeriodClose{
+		{PeriodStart: day(time.February, 1), PeriodEnd: day(time.February, 28)},
+		{PeriodStart: day(time.April, 1), PeriodEnd: day(time.April, 30)},
+	}
+	tests := []struct {
+		name string
+		from time.Time
+		want string
+	}{
+		{"before every closed period", day(time.January, 10), "2026-01-10..2026-02-01 2026-03-01..2026-04-01 2026-05-01.."},
+		{"inside a closed period", day(time.February, 10), "2026-03-01..2026-04-01 2026-05-01.."},
+		{"between closed periods", day(time.March, 5), "2026-03-05..2026-04-01 2026-05-01.."},
+		{"after every closed period", day(time.June, 1), "2026-06-01.."},
+	}
+	for _, tt := range tests {
+		var got string
+		for i, span := range u.openRanges(closed, tt.from) {
+			if i > 0 {
+				got += " "
+			}
+			got += span.start.Format("2006-01-02") + ".."
+			if !span.end.IsZero() {
+				got += span.end.Format("2006-01-02")
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s: ranges = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// A rebuild starting before a closed month rebuilds the open days before
+// it, leaves the month as it was closed and carries its stored closing
+// balance into the days after it.
+func TestRecalculateBalancesBeforeClosedMonth(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC) }
+	at := func(month time.Month, d int) time.Time { return day(month, d).Add(9 * time.Hour) }
+	repo := &fakeBalanceRepository{
+		opening: 1000,
+		closed:  []domain.PeriodClose{{PeriodStart: day(time.March, 1), PeriodEnd: day(time.March, 31)}},
+		balances: map[string]domain.CashBalance{
+			"2026-01-05": {Date: day(time.January, 5)},
+			"2026-03-10": {Date: day(time.March, 10), OpeningBalance: 4000, TotalIn: 1000, ClosingBalance: 5000},
+			"2026-04-02": {Date: day(time.April, 2)},
+		},
+		transactions: []domain.CashTransaction{
+			{Type: "in", Amount: 300, TransactionDate: at(time.January, 5)},
+			{Type: "out", Amount: 100, TransactionDate: at(time.February, 3)},
+			{Type: "in", Amount: 777, TransactionDate: at(time.March, 10)},
+			{Type: "in", Amount: 50, TransactionDate: at(time.April, 2)},
+		},
+	}
+	u := &cashUsecase{location: time.UTC}
+
+	if err := u.recalculateBalances(repo, 1, day(time.January, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"2026-01-05": "opening 10.00 in 3.00 out 0.00 closing 13.00",
+		"2026-02-03": "opening 13.00 in 0.00 out 1.00 closing 12.00",
+		"2026-03-10": "opening 40.00 in 10.00 out 0.00 closing 50.00",
+		"2026-04-02": "opening 50.00 in 0.50 out 0.00 closing 50.50",
+	}
+	if len(repo.balances) != len(want) {
+		t.Errorf("%d days stored, want %d", len(repo.balances), len(want))
+	}
+	for key, expected := range want {
+		balance := repo.balances[key]
+		got := fmt.Sprintf("opening %s in %s out %s closing %s",
+			balance.OpeningBalance, balance.TotalIn, balance.TotalOut, balance.ClosingBalance)
+		if got != expected {
+			t.Errorf("%s: %s, want %s", key, got, expected)
+		}
+	}
+}
@@ -0,0 +1,510 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	defaultMatchDays = 3
+	// maxMatchDays is how far outside the period a matched transaction may
+	// lie; it bounds the fuzzy date tolerance and manual matches.
+	maxMatchDays = 31
+	// minMatchScore is the lowest fuzzy score accepted as a match.
+	minMatchScore = 0.5
+)
+
+// CreateReconciliation opens a reconciliation of an account for a period,
+// with the statement's closing balance and optionally its lines.
+func (u *cashUsecase) CreateReconciliation(input domain.Reconciliation) (*domain.Reconciliation, error) {
+	if input.PeriodStart.IsZero() || input.PeriodEnd.IsZero() {
+		return nil, ErrReconciliationPeriodRequired
+	}
+	input.PeriodStart, input.PeriodEnd = u.bookDay(input.PeriodStart), u.bookDay(input.PeriodEnd)
+	if input.PeriodStart.After(input.PeriodEnd) {
+		return nil, ErrInvalidDateRange
+	}
+	if err := checkAccount(u.repo, input.AccountID, 0); err != nil {
+		return nil, err
+	}
+
+	for i := range input.Lines {
+		line := &input.Lines[i]
+		line.ID, line.ReconciliationID = 0, 0
+		line.TransactionID, line.MatchType = nil, ""
+		line.Date = u.bookDay(line.Date)
+		if err := u.checkStatementLine(&input, line.Date, line.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	input.ID = 0
+	input.Status = domain.ReconciliationOpen
+	input.CompletedAt, input.CompletedBy = nil, nil
+	input.Account = nil
+	if err := u.repo.CreateReconciliation(&input); err != nil {
+		return nil, err
+	}
+	return u.repo.GetReconciliationByID(input.ID)
+}
+
+func (u *cashUsecase) checkStatementLine(reconciliation *domain.Reconciliation, date time.Time, amount domain.Money) error {
+	if amount.IsZero() {
+		return ErrInvalidAmount
+	}
+	if date.Before(u.calendarDay(reconciliation.PeriodStart)) || date.After(u.calendarDay(reconciliation.PeriodEnd)) {
+		return ErrStatementLineOutOfPeriod
+	}
+	return nil
+}
+
+// LoadStatement adds the lines of a parsed bank statement that fall in the
+// reconciliation's period and are not loaded yet, and takes over the
+// statement's closing balance when it states one. References are cut to
+// the length of the column; a longer one cannot match a bank reference.
+func (u *cashUsecase) LoadStatement(id uint, statement *domain.BankStatement) (*domain.ReconciliationReport, error) {
+	err := u.write(func(repo repository.CashRepository) error {
+		reconciliation, err := openReconciliation(repo, id)
+		if err != nil {
+			return err
+		}
+
+		loaded := make(map[string]bool, len(reconciliation.Lines))
+		for _, line := range reconciliation.Lines {
+			if line.Reference != "" {
+				loaded[line.Reference] = true
+			}
+		}
+
+		var lines []domain.ReconciliationLine
+		for _, line := range statement.Lines {
+			date := u.bookDay(line.Date)
+			reference := truncateText(line.Reference, maxBankReferenceLength)
+			if loaded[reference] || u.checkStatementLine(reconciliation, date, line.Amount) != nil {
+				continue
+			}
+			loaded[reference] = true
+			lines = append(lines, domain.ReconciliationLine{
+				ReconciliationID: id,
+				Date:             date,
+				Amount:           line.Amount,
+				Reference:        reference,
+				Description:      line.Description,
+			})
+		}
+		if err := repo.CreateReconciliationLines(lines); err != nil {
+			return err
+		}
+
+		if statement.ClosingBalance != nil {
+			reconciliation.StatementBalance = *statement.ClosingBalance
+			return repo.UpdateReconciliation(reconciliation)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetReconciliationReport(id)
+}
+
+func (u *cashUsecase) GetReconciliations(accountID uint) ([]domain.Reconciliation, error) {
+	return u.repo.GetReconciliations(accountID)
+}
+
+func (u *cashUsecase) GetReconciliationReport(id uint) (*domain.ReconciliationReport, error) {
+	reconciliation, err := u.repo.GetReconciliationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if reconciliation == nil {
+		return nil, ErrReconciliationNotFound
+	}
+	return u.reconciliationReport(u.repo, reconciliation)
+}
+
+// MatchReconciliation matches the unmatched statement lines against the
+// account's transactions: first by bank reference, then by equal amount on
+// the same day, then fuzzily by amount, date and description within the
+// tolerances of options.
+func (u *cashUsecase) MatchReconciliation(id uint, options domain.MatchOptions) (*domain.ReconciliationReport, error) {
+	if options.DateTolerance == 0 {
+		options.DateTolerance = defaultMatchDays
+	}
+	if options.DateTolerance < 0 || options.DateTolerance > maxMatchDays || options.AmountTolerance < 0 {
+		return nil, ErrInvalidMatchTolerance
+	}
+
+	err := u.write(func(repo repository.CashRepository) error {
+		reconciliation, err := openReconciliation(repo, id)
+		if err != nil {
+			return err
+		}
+		candidates, err := u.reconciliationCandidates(repo, reconciliation)
+		if err != nil {
+			return err
+		}
+
+		matched := make(map[uint]bool)
+		var lines []*domain.ReconciliationLine
+		for i := range reconciliation.Lines {
+			line := &reconciliation.Lines[i]
+			if line.IsMatched() {
+				matched[*line.TransactionID] = true
+			} else {
+				lines = append(lines, line)
+			}
+		}
+		var available []domain.CashTransaction
+		for _, transaction := range candidates {
+			if !matched[transaction.ID] {
+				available = append(available, transaction)
+			}
+		}
+
+		for _, line := range u.matchLines(lines, available, options) {
+			if err := repo.UpdateReconciliationLine(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetReconciliationReport(id)
+}
+
+// MatchReconciliationLine matches a statement line to a transaction by
+// hand, replacing any earlier match of the line.
+func (u *cashUsecase) MatchReconciliationLine(id, lineID, transactionID uint) (*domain.ReconciliationReport, error) {
+	err := u.write(func(repo repository.CashRepository) error {
+		reconciliation, err := openReconciliation(repo, id)
+		if err != nil {
+			return err
+		}
+		line := findReconciliationLine(reconciliation, lineID)
+		if line == nil {
+			return ErrReconciliationLineNotFound
+		}
+		for _, other := range reconciliation.Lines {
+			if other.ID != lineID && other.TransactionID != nil && *other.TransactionID == transactionID {
+				return ErrTransactionAlreadyMatched
+			}
+		}
+
+		candidates, err := u.reconciliationCandidates(repo, reconciliation)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, transaction := range candidates {
+			found = found || transaction.ID == transactionID
+		}
+		if !found {
+			return ErrTransactionNotMatchable
+		}
+
+		line.TransactionID, line.MatchType = &transactionID, domain.MatchManual
+		return repo.UpdateReconciliationLine(line)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetReconciliationReport(id)
+}
+
+func (u *cashUsecase) UnmatchReconciliationLine(id, lineID uint) (*domain.ReconciliationReport, error) {
+	err := u.write(func(repo repository.CashRepository) error {
+		reconciliation, err := openReconciliation(repo, id)
+		if err != nil {
+			return err
+		}
+		line := findReconciliationLine(reconciliation, lineID)
+		if line == nil {
+			return ErrReconciliationLineNotFound
+		}
+		line.TransactionID, line.MatchType = nil, ""
+		return repo.UpdateReconciliationLine(line)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetReconciliationReport(id)
+}
+
+// CompleteReconciliation closes a reconciliation whose difference is fully
+// explained by unmatched items and marks the matched transactions as
+// reconciled, which keeps them from being edited or voided afterwards.
+func (u *cashUsecase) CompleteReconciliation(id, userID uint) (*domain.ReconciliationReport, error) {
+	err := u.write(func(repo repository.CashRepository) error {
+		reconciliation, err := openReconciliation(repo, id)
+		if err != nil {
+			return err
+		}
+		if err := lockAccounts(repo, reconciliation.AccountID); err != nil {
+			return err
+		}
+		report, err := u.reconciliationReport(repo, reconciliation)
+		if err != nil {
+			return err
+		}
+		if !report.UnexplainedDifference.IsZero() {
+			return fmt.Errorf("%w: %s", ErrReconciliationUnbalanced, report.UnexplainedDifference)
+		}
+
+		ids := make([]uint, 0, len(report.Matched))
+		for _, match := range report.Matched {
+			ids = append(ids, match.Transaction.ID)
+		}
+		now := time.Now()
+		if err := repo.MarkTransactionsReconciled(ids, reconciliation.ID, now); err != nil {
+			return err
+		}
+
+		reconciliation.Status = domain.ReconciliationCompleted
+		reconciliation.CompletedAt = &now
+		reconciliation.CompletedBy = &userID
+		return repo.UpdateReconciliation(reconciliation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetReconciliationReport(id)
+}
+
+func (u *cashUsecase) reconciliationReport(repo repository.CashRepository, reconciliation *domain.Reconciliation) (*domain.ReconciliationReport, error) {
+	end := u.calendarDay(reconciliation.PeriodEnd).AddDate(0, 0, 1)
+	bookBalance, err := repo.GetOpeningBalance(reconciliation.AccountID, end)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := u.reconciliationCandidates(repo, reconciliation)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]domain.CashTransaction, len(candidates))
+	for _, transaction := range candidates {
+		byID[transaction.ID] = transaction
+	}
+
+	report := &domain.ReconciliationReport{
+		Reconciliation:          reconciliation,
+		BookBalance:             bookBalance,
+		StatementBalance:        reconciliation.StatementBalance,
+		Difference:              reconciliation.StatementBalance - bookBalance,
+		Matched:                 []domain.ReconciliationMatch{},
+		UnmatchedStatementLines: []domain.ReconciliationLine{},
+		UnmatchedTransactions:   []domain.CashTransaction{},
+	}
+
+	matched := make(map[uint]bool)
+	for _, line := range reconciliation.Lines {
+		transaction, ok := domain.CashTransaction{}, false
+		if line.IsMatched() {
+			transaction, ok = byID[*line.TransactionID]
+		}
+		if !ok {
+			report.UnmatchedStatementLines = append(report.UnmatchedStatementLines, line)
+			report.UnmatchedStatementTotal += line.Amount
+			continue
+		}
+		matched[transaction.ID] = true
+		report.Matched = append(report.Matched, domain.ReconciliationMatch{Line: line, Transaction: transaction})
+		report.MatchedDifference += line.Amount - transaction.SignedAmount()
+	}
+
+	start := u.calendarDay(reconciliation.PeriodStart)
+	for _, transaction := range candidates {
+		if matched[transaction.ID] || transaction.TransactionDate.Before(start) || !transaction.TransactionDate.Before(end) {
+			continue
+		}
+		report.UnmatchedTransactions = append(report.UnmatchedTransactions, transaction)
+		report.UnmatchedBookTotal += transaction.SignedAmount()
+	}
+
+	report.UnexplainedDifference = report.Difference - report.UnmatchedStatementTotal +
+		report.UnmatchedBookTotal - report.MatchedDifference
+	return report, nil
+}
+
+// reconciliationCandidates loads the transactions a line of the
+// reconciliation may be matched to, reaching maxMatchDays beyond the period.
+func (u *cashUsecase) reconciliationCandidates(repo repository.CashRepository, reconciliation *domain.Reconciliation) ([]domain.CashTransaction, error) {
+	return repo.GetReconciliationCandidates(
+		reconciliation.AccountID,
+		u.calendarDay(reconciliation.PeriodStart).AddDate(0, 0, -maxMatchDays),
+		u.calendarDay(reconciliation.PeriodEnd).AddDate(0, 0, maxMatchDays+1),
+		reconciliation.ID,
+	)
+}
+
+// matchLines pairs statement lines with transactions in three passes and
+// returns the lines it matched.
+func (u *cashUsecase) matchLines(lines []*domain.ReconciliationLine, transactions []domain.CashTransaction, options domain.MatchOptions) []*domain.ReconciliationLine {
+	used := make(map[uint]bool)
+	var result []*domain.ReconciliationLine
+	match := func(line *domain.ReconciliationLine, transaction domain.CashTransaction, matchType string) {
+		id := transaction.ID
+		line.TransactionID, line.MatchType = &id, matchType
+		used[id] = true
+		result = append(result, line)
+	}
+
+	// Bank reference, as stored by the statement import.
+	for _, line := range lines {
+		for _, transaction := range transactions {
+			if line.Reference != "" && transaction.BankReference != nil && *transaction.BankReference == line.Reference &&
+				transaction.SignedAmount() == line.Amount && !used[transaction.ID] {
+				match(line, transaction, domain.MatchExact)
+				break
+			}
+		}
+	}
+
+	// Same amount on the same day; the closest description wins a tie.
+	for _, line := range lines {
+		if line.IsMatched() {
+			continue
+		}
+		best, bestScore := -1, -1.0
+		for i, transaction := range transactions {
+			if used[transaction.ID] || transaction.SignedAmount() != line.Amount || u.dayDiff(line.Date, transaction.TransactionDate) != 0 {
+				continue
+			}
+			if score := textSimilarity(line.Description, transaction.Description); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 {
+			match(line, transactions[best], domain.MatchExact)
+		}
+	}
+
+	// Fuzzy: score every remaining pair within the tolerances and take the
+	// best pairs first.
+	type pair struct {
+		line        *domain.ReconciliationLine
+		transaction int
+		score       float64
+	}
+	var pairs []pair
+	for _, line := range lines {
+		if line.IsMatched() {
+			continue
+		}
+		for i, transaction := range transactions {
+			if used[transaction.ID] {
+				continue
+			}
+			amountDiff := (line.Amount - transaction.SignedAmount()).Abs()
+			days := u.dayDiff(line.Date, transaction.TransactionDate)
+			if days < 0 {
+				days = -days
+			}
+			if amountDiff > options.AmountTolerance || days > options.DateTolerance ||
+				(line.Amount < 0) != (transaction.SignedAmount() < 0) {
+				continue
+			}
+			score := 0.5*(1-float64(amountDiff)/float64(options.AmountTolerance+1)) +
+				0.3*(1-float64(days)/float64(options.DateTolerance+1)) +
+				0.2*textSimilarity(line.Description, transaction.Description)
+			if score >= minMatchScore {
+				pairs = append(pairs, pair{line: line, transaction: i, score: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].score > pairs[j].score })
+	for _, p := range pairs {
+		if p.line.IsMatched() || used[transactions[p.transaction].ID] {
+			continue
+		}
+		match(p.line, transactions[p.transaction], domain.MatchFuzzy)
+	}
+	return result
+}
+
+// calendarDay reads a value of a date column, which carries no zone, as
+// midnight of that day in the book's time zone.
+func (u *cashUsecase) calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, u.location)
+}
+
+// dayDiff is the number of calendar days from the statement day to the
+// transaction's book day.
+func (u *cashUsecase) dayDiff(statementDay, transactionDate time.Time) int {
+	year, month, day := statementDay.Date()
+	a := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	year, month, day = transactionDate.In(u.location).Date()
+	b := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// textSimilarity is the Jaccard similarity of the words of a and b, from 0
+// for nothing in common to 1 for the same words.
+func textSimilarity(a, b string) float64 {
+	words := func(text string) map[string]bool {
+		set := make(map[string]bool)
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len(word) >= 3 {
+				set[word] = true
+			}
+		}
+		return set
+	}
+	left, right := words(a), words(b)
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	common := 0
+	for word := range left {
+		if right[word] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(left)+len(right)-common)
+}
+
+func openReconciliation(repo repository.CashRepository, id uint) (*domain.Reconciliation, error) {
+	reconciliation, err := repo.GetReconciliationForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+	if reconciliation == nil {
+		return nil, ErrReconciliationNotFound
+	}
+	if reconciliation.IsCompleted() {
+		return nil, ErrReconciliationCompleted
+	}
+	return reconciliation, nil
+}
+
+func findReconciliationLine(reconciliation *domain.Reconciliation, lineID uint) *domain.ReconciliationLine {
+	for i := range reconciliation.Lines {
+		if reconciliation.Lines[i].ID == lineID {
+			return &reconciliation.Lines[i]
+		}
+	}
+	return nil
+}
+
+var (
+	ErrReconciliationNotFound       = fmt.Errorf("rekonsiliasi tidak ditemukan")
+	ErrReconciliationLineNotFound   = fmt.Errorf("baris mutasi rekonsiliasi tidak ditemukan")
+	ErrReconciliationPeriodRequired = fmt.Errorf("periode rekonsiliasi wajib diisi")
+	ErrReconciliationCompleted      = fmt.Errorf("rekonsiliasi sudah selesai")
+	ErrReconciliationUnbalanced     = fmt.Errorf("selisih rekonsiliasi belum terjelaskan")
+	ErrStatementLineOutOfPeriod     = fmt.Errorf("tanggal baris mutasi di luar periode rekonsiliasi")
+	ErrInvalidMatchTolerance        = fmt.Errorf("toleransi pencocokan tidak valid: toleransi hari 0-31 dan toleransi nominal tidak boleh negatif")
+	ErrTransactionAlreadyMatched    = fmt.Errorf("transaksi sudah dicocokkan dengan baris mutasi lain")
+	ErrTransactionNotMatchable      = fmt.Errorf("transaksi tidak dapat dicocokkan: harus pada akun yang sama, dekat periode, tidak dibatalkan dan belum direkonsiliasi")
+	ErrTransactionReconciled        = fmt.Errorf("transaksi sudah direkonsiliasi dan tidak dapat diubah")
+)
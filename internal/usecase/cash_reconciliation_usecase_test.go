@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMatchLines(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	u := &cashUsecase{location: jakarta}
+
+	// Statement days are date columns: midnight UTC of the day.
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d, hour, minute int) time.Time { return time.Date(2026, 1, d, hour, minute, 0, 0, jakarta) }
+	reference := func(s string) *string { return &s }
+	defaults := domain.MatchOptions{DateTolerance: defaultMatchDays}
+
+	tests := []struct {
+		name         string
+		lines        []domain.ReconciliationLine
+		transactions []domain.CashTransaction
+		options      domain.MatchOptions
+		want         []string
+	}{
+		{
+			name:  "bank reference before same day",
+			lines: []domain.ReconciliationLine{{Date: day(5), Amount: 100000, Reference: "R1"}},
+			transactions: []domain.CashTransaction{
+				{ID: 1, Type: "in", Amount: 100000, TransactionDate: at(5, 9, 0)},
+				{ID: 2, Type: "in", Amount: 100000, TransactionDate: at(8, 9, 0), BankReference: reference("R1")},
+			},
+			options: defaults,
+			want:    []string{"2 exact"},
+		},
+		{
+			name:  "bank reference with another amount",
+			lines: []domain.ReconciliationLine{{Date: day(5), Amount: 100000, Reference: "R1"}},
+			transactions: []domain.CashTransaction{
+				{ID: 1, Type: "out", Amount: 100000, TransactionDate: at(5, 9, 0), BankReference: reference("R1")},
+			},
+			options: defaults,
+			want:    []string{"-"},
+		},
+		{
+			name:  "same day tie broken by description",
+			lines: []domain.ReconciliationLine{{Date: day(5), Amount: -250000, Description: "Biaya admin bulanan"}},
+			transactions: []domain.CashTransaction{
+				{ID: 1, Type: "out", Amount: 250000, TransactionDate: at(5, 8, 0), Description: "Pembelian ATK"},
+				{ID: 2, Type: "out", Amount: 250000, TransactionDate: at(5, 10, 0), Description: "Biaya admin bank"},
+			},
+			options: defaults,
+			want:    []string{"2 exact"},
+		},
+		{
+			name: "a transaction is matched once",
+			lines: []domain.ReconciliationLine{
+				{Date: day(5), Amount: 500000},
+				{Date: day(5), Amount: 500000},
+			},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "in", Amount: 500000, TransactionDate: at(5, 9, 0)}},
+			options:      defaults,
+			want:         []string{"1 exact", "-"},
+		},
+		{
+			name:         "fuzzy within the date tolerance",
+			lines:        []domain.ReconciliationLine{{Date: day(5), Amount: -1000000}},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "out", Amount: 1000000, TransactionDate: at(6, 9, 0)}},
+			options:      defaults,
+			want:         []string{"1 fuzzy"},
+		},
+		{
+			name:         "fuzzy within the amount tolerance",
+			lines:        []domain.ReconciliationLine{{Date: day(5), Amount: 1000500}},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "in", Amount: 1000000, TransactionDate: at(5, 9, 0)}},
+			options:      domain.MatchOptions{DateTolerance: defaultMatchDays, AmountTolerance: 1000},
+			want:         []string{"1 fuzzy"},
+		},
+		{
+			name:         "outside the date tolerance",
+			lines:        []domain.ReconciliationLine{{Date: day(5), Amount: 1000000}},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "in", Amount: 1000000, TransactionDate: at(9, 9, 0)}},
+			options:      defaults,
+			want:         []string{"-"},
+		},
+		{
+			name:         "outside the amount tolerance",
+			lines:        []domain.ReconciliationLine{{Date: day(5), Amount: 1002000}},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "in", Amount: 1000000, TransactionDate: at(5, 9, 0)}},
+			options:      domain.MatchOptions{DateTolerance: defaultMatchDays, AmountTolerance: 1000},
+			want:         []string{"-"},
+		},
+		{
+			name:         "opposite sign",
+			lines:        []domain.ReconciliationLine{{Date: day(5), Amount: 1000}},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "out", Amount: 1000, TransactionDate: at(5, 9, 0)}},
+			options:      domain.MatchOptions{DateTolerance: defaultMatchDays, AmountTolerance: 5000},
+			want:         []string{"-"},
+		},
+		{
+			name:         "book day taken in the book's time zone",
+			lines:        []domain.ReconciliationLine{{Date: day(6), Amount: 1000}},
+			transactions: []domain.CashTransaction{{ID: 1, Type: "in", Amount: 1000, TransactionDate: at(6, 0, 30).UTC()}},
+			options:      domain.MatchOptions{},
+			want:         []string{"1 exact"},
+		},
+		{
+			name: "best fuzzy pair first",
+			lines: []domain.ReconciliationLine{
+				{Date: day(5), Amount: -300000, Description: "Sewa gudang"},
+			},
+			transactions: []domain.CashTransaction{
+				{ID: 1, Type: "out", Amount: 300000, TransactionDate: at(7, 9, 0), Description: "Listrik"},
+				{ID: 2, Type: "out", Amount: 300000, TransactionDate: at(6, 9, 0), Description: "Sewa gudang Januari"},
+			},
+			options: defaults,
+			want:    []string{"2 fuzzy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make([]*domain.ReconciliationLine, len(tt.lines))
+			for i := range tt.lines {
+				lines[i] = &tt.lines[i]
+			}
+			matched := u.matchLines(lines, tt.transactions, tt.options)
+
+			var got []string
+			count := 0
+			for _, line := range lines {
+				if !line.IsMatched() {
+					got = append(got, "-")
+					continue
+				}
+				count++
+				got = append(got, fmt.Sprintf("%d %s", *line.TransactionID, line.MatchType))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matches = %q, want %q", got, tt.want)
+			}
+			if len(matched) != count {
+				t.Errorf("returned %d matched lines, want %d", len(matched), count)
+			}
+		})
+	}
+}
+
+func TestTextSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Biaya admin", "biaya ADMIN", 1},
+		{"Biaya admin", "Biaya admin bank", 2.0 / 3},
+		{"Sewa", "Listrik", 0},
+		{"TRF/INV-123", "inv 123 trf", 1},
+		{"a b c", "a b c", 0},
+		{"", "Biaya", 0},
+	}
+	for _, tt := range tests {
+		if got := textSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("textSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
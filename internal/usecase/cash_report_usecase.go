@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"sort"
+	"time"
+)
+
+// GetSummaryReport aggregates the book between the start and end days
+// (inclusive) per period of groupBy, with the running balance at the end of
+// each period. byCategory adds a per-category breakdown to every period.
+func (u *cashUsecase) GetSummaryReport(accountID uint, start, end time.Time, groupBy string, byCategory bool) (*domain.SummaryReport, error) {
+	if groupBy == "" {
+		groupBy = domain.GroupByMonth
+	}
+	if !isValidGroupBy(groupBy) {
+		return nil, ErrInvalidGroupBy
+	}
+
+	start, end, err := u.reportPeriod(start, end)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := reportBuckets(start, end, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountID != 0 {
+		if err := checkAccountExists(u.repo, accountID); err != nil {
+			return nil, err
+		}
+	}
+
+	opening, err := u.openingBalance(accountID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.repo.AggregateTransactions(domain.TransactionFilter{
+		AccountID: accountID,
+		Start:     start,
+		End:       end.AddDate(0, 0, 1),
+	}, groupBy, u.location)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.repo.GetAllCategories(true)
+	if err != nil {
+		return nil, err
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	report := &domain.SummaryReport{
+		AccountID:      accountID,
+		Start:          start,
+		End:            end,
+		GroupBy:        groupBy,
+		OpeningBalance: opening,
+		Buckets:        periods,
+	}
+
+	buckets := make(map[string]*domain.ReportBucket)
+	bucketCategories := make(map[string]map[uint]*domain.CategoryTotal)
+	for i := range report.Buckets {
+		key := truncatePeriod(report.Buckets[i].PeriodStart, groupBy).Format("2006-01-02")
+		buckets[key] = &report.Buckets[i]
+		bucketCategories[key] = make(map[uint]*domain.CategoryTotal)
+	}
+
+	totals := make(map[uint]*domain.CategoryTotal)
+	for _, row := range rows {
+		key := row.Period.Format("2006-01-02")
+		bucket, ok := buckets[key]
+		if !ok {
+			continue
+		}
+
+		if row.Kind == domain.TransactionKindTransfer {
+			bucket.TransferIn += row.TotalIn
+			bucket.TransferOut += row.TotalOut
+			continue
+		}
+
+		bucket.TotalIn += row.TotalIn
+		bucket.TotalOut += row.TotalOut
+		addCategoryTotal(totals, categoryNames, row)
+		if byCategory {
+			addCategoryTotal(bucketCategories[key], categoryNames, row)
+		}
+	}
+
+	balance := opening
+	for i := range report.Buckets {
+		bucket := &report.Buckets[i]
+		bucket.Net = bucket.TotalIn - bucket.TotalOut
+		balance += bucket.Net + bucket.TransferIn - bucket.TransferOut
+		bucket.RunningBalance = balance
+
+		report.TotalIn += bucket.TotalIn
+		report.TotalOut += bucket.TotalOut
+		if byCategory {
+			key := truncatePeriod(bucket.PeriodStart, groupBy).Format("2006-01-02")
+			bucket.Categories = sortedCategoryTotals(bucketCategories[key])
+		}
+	}
+	report.Net = report.TotalIn - report.TotalOut
+	report.ClosingBalance = balance
+	report.Categories = sortedCategoryTotals(totals)
+	return report, nil
+}
+
+// maxReportBuckets caps the periods of a summary report, e.g. a year of
+// days or 30 years of months.
+const maxReportBuckets = 366
+
+// reportBuckets lays out the empty periods of groupBy covering start
+// through end, the first and last cut to the range.
+func reportBuckets(start, end time.Time, groupBy string) ([]domain.ReportBucket, error) {
+	var buckets []domain.ReportBucket
+	for period := truncatePeriod(start, groupBy); !period.After(end); period = nextPeriod(period, groupBy) {
+		if len(buckets) == maxReportBuckets {
+			return nil, ErrReportTooLong
+		}
+		bucket := domain.ReportBucket{PeriodStart: period, PeriodEnd: nextPeriod(period, groupBy).AddDate(0, 0, -1)}
+		if bucket.PeriodStart.Before(start) {
+			bucket.PeriodStart = start
+		}
+		if bucket.PeriodEnd.After(end) {
+			bucket.PeriodEnd = end
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// reportPeriod turns the requested inclusive day range of a report into
+// book days, defaulting to the start of the current month through today.
+func (u *cashUsecase) reportPeriod(start, end time.Time) (time.Time, time.Time, error) {
+	today := u.bookDay(time.Now())
+	if end.IsZero() {
+		end = today
+	}
+	if start.IsZero() {
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, u.location)
+	}
+	start, end = u.bookDay(start), u.bookDay(end)
+	if start.After(end) {
+		return start, end, ErrInvalidDateRange
+	}
+	return start, end, nil
+}
+
+// openingBalance is the money held at the start of date in the account, or
+// in every account (archived ones included) when accountID is 0.
+func (u *cashUsecase) openingBalance(accountID uint, date time.Time) (domain.Money, error) {
+	if accountID != 0 {
+		return u.repo.GetOpeningBalance(accountID, date)
+	}
+
+	accounts, err := u.repo.GetAllAccounts(true)
+	if err != nil {
+		return 0, err
+	}
+	var total domain.Money
+	for _, account := range accounts {
+		opening, err := u.repo.GetOpeningBalance(account.ID, date)
+		if err != nil {
+			return 0, err
+		}
+		total += opening
+	}
+	return total, nil
+}
+
+func addCategoryTotal(totals map[uint]*domain.CategoryTotal, names map[uint]string, row domain.ReportRow) {
+	total, ok := totals[row.CategoryID]
+	if !ok {
+		total = &domain.CategoryTotal{CategoryID: row.CategoryID, CategoryName: names[row.CategoryID]}
+		totals[row.CategoryID] = total
+	}
+	total.TotalIn += row.TotalIn
+	total.TotalOut += row.TotalOut
+	total.Net = total.TotalIn - total.TotalOut
+}
+
+func sortedCategoryTotals(totals map[uint]*domain.CategoryTotal) []domain.CategoryTotal {
+	result := make([]domain.CategoryTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CategoryName != result[j].CategoryName {
+			return result[i].CategoryName < result[j].CategoryName
+		}
+		return result[i].CategoryID < result[j].CategoryID
+	})
+	return result
+}
+
+func isValidGroupBy(groupBy string) bool {
+	switch groupBy {
+	case domain.GroupByDay, domain.GroupByWeek, domain.GroupByMonth, domain.GroupByYear:
+		return true
+	}
+	return false
+}
+
+// truncatePeriod returns the first day of the period containing day, with
+// weeks starting on Monday like PostgreSQL's date_trunc.
+func truncatePeriod(day time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case domain.GroupByWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case domain.GroupByMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	case domain.GroupByYear:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+	}
+	return day
+}
+
+func nextPeriod(period time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case domain.GroupByWeek:
+		return period.AddDate(0, 0, 7)
+	case domain.GroupByMonth:
+		return period.AddDate(0, 1, 0)
+	case domain.GroupByYear:
+		return period.AddDate(1, 0, 0)
+	}
+	return period.AddDate(0, 0, 1)
+}
+
+var (
+	ErrInvalidGroupBy   = fmt.Errorf("pengelompokan tidak valid: harus day, week, month atau year")
+	ErrInvalidDateRange = fmt.Errorf("tanggal mulai tidak boleh setelah tanggal akhir")
+	ErrReportTooLong    = fmt.Errorf("rentang laporan terlalu panjang: paling banyak 366 periode, pilih pengelompokan yang lebih besar")
+)
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"go-project/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestReportBuckets(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name       string
+		start, end time.Time
+		groupBy    string
+		want       int
+		wantErr    error
+	}{
+		{"a leap year of days", day(2024, time.January, 1), day(2024, time.December, 31), domain.GroupByDay, 366, nil},
+		{"one day too many", day(2024, time.January, 1), day(2025, time.January, 1), domain.GroupByDay, 0, ErrReportTooLong},
+		{"months cut to the range", day(2026, time.January, 15), day(2026, time.March, 10), domain.GroupByMonth, 3, nil},
+		{"a century of years", day(1926, time.June, 1), day(2026, time.June, 1), domain.GroupByYear, 101, nil},
+	}
+	for _, tt := range tests {
+		buckets, err := reportBuckets(tt.start, tt.end, tt.groupBy)
+		if err != tt.wantErr {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if len(buckets) != tt.want {
+			t.Errorf("%s: %d buckets, want %d", tt.name, len(buckets), tt.want)
+			continue
+		}
+		if tt.want > 0 && (!buckets[0].PeriodStart.Equal(tt.start) || !buckets[len(buckets)-1].PeriodEnd.Equal(tt.end)) {
+			t.Errorf("%s: buckets span %s..%s, want %s..%s", tt.name,
+				buckets[0].PeriodStart.Format("2006-01-02"), buckets[len(buckets)-1].PeriodEnd.Format("2006-01-02"),
+				tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"))
+		}
+	}
+}
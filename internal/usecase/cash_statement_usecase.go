@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	bankPaymentMethod = "bank"
+	// maxBankReferenceLength is the size of the bank_reference column.
+	maxBankReferenceLength = 100
+)
+
+// ImportStatement turns the lines of a bank statement into transactions of
+// the chosen account. Lines whose bank reference was imported before, or
+// repeats one earlier in the same file, are skipped; lines that cannot be
+// booked (no category for their direction, a zero amount, a reference too
+// long to store, a future date or a date in a closed period) are reported
+// as unmatched. The new transactions are stored together and the account's
+// balances are rebuilt once from the earliest of them.
+func (u *cashUsecase) ImportStatement(statement *domain.BankStatement, options domain.StatementImport) (*domain.StatementImportResult, error) {
+	if err := checkAccount(u.repo, options.AccountID, 0); err != nil {
+		return nil, err
+	}
+	for _, category := range []struct {
+		id              uint
+		transactionType string
+	}{
+		{options.InCategoryID, "in"},
+		{options.OutCategoryID, "out"},
+	} {
+		if category.id == 0 {
+			continue
+		}
+		if err := checkCategory(u.repo, category.id, category.transactionType, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &domain.StatementImportResult{
+		Format:         statement.Format,
+		AccountID:      options.AccountID,
+		DryRun:         options.DryRun,
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+	}
+
+	var pending []int
+	var transactions []*domain.CashTransaction
+	err := u.write(func(repo repository.CashRepository) error {
+		if err := lockAccounts(repo, options.AccountID); err != nil {
+			return err
+		}
+		closed, err := repo.GetClosedPeriods()
+		if err != nil {
+			return err
+		}
+
+		references := make([]string, 0, len(statement.Lines))
+		for _, line := range statement.Lines {
+			references = append(references, line.Reference)
+		}
+		imported, err := repo.GetBankReferences(options.AccountID, references)
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool, len(imported))
+		for _, reference := range imported {
+			seen[reference] = true
+		}
+
+		result.Lines = make([]domain.StatementLineResult, 0, len(statement.Lines))
+		for _, line := range statement.Lines {
+			lineResult := domain.StatementLineResult{StatementLine: line}
+			transaction, reason := u.statementTransaction(line, options)
+			switch {
+			case seen[line.Reference]:
+				lineResult.Status, lineResult.Reason = domain.StatementLineSkipped, "sudah pernah diimpor"
+				result.Skipped++
+			case transaction == nil:
+				lineResult.Status, lineResult.Reason = domain.StatementLineUnmatched, reason
+				result.Unmatched++
+			case u.closedPeriodAt(closed, line.Date) != nil:
+				lineResult.Status, lineResult.Reason = domain.StatementLineUnmatched, ErrPeriodClosed.Error()
+				result.Unmatched++
+			default:
+				lineResult.Status = domain.StatementLineCreated
+				result.Created++
+				pending = append(pending, len(result.Lines))
+				transactions = append(transactions, transaction)
+			}
+			seen[line.Reference] = true
+			result.Lines = append(result.Lines, lineResult)
+		}
+		if options.DryRun || len(transactions) == 0 {
+			return nil
+		}
+
+		from := time.Time{}
+		for _, transaction := range transactions {
+			if err := repo.CreateTransaction(transaction); err != nil {
+				return err
+			}
+			if day := u.bookDay(transaction.TransactionDate); from.IsZero() || day.Before(from) {
+				from = day
+			}
+		}
+		return u.recalculateBalances(repo, options.AccountID, from)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, index := range pending {
+		result.Lines[index].TransactionID = transactions[i].ID
+	}
+	return result, nil
+}
+
+// statementTransaction builds the transaction for a statement line, or
+// explains why the line cannot be booked.
+func (u *cashUsecase) statementTransaction(line domain.StatementLine, options domain.StatementImport) (*domain.CashTransaction, string) {
+	if line.Amount.IsZero() {
+		return nil, "nominal nol"
+	}
+	if utf8.RuneCountInString(line.Reference) > maxBankReferenceLength {
+		return nil, fmt.Sprintf("referensi bank lebih dari %d karakter", maxBankReferenceLength)
+	}
+	if u.isFutureDate(line.Date) {
+		return nil, ErrFutureTransactionDate.Error()
+	}
+
+	transactionType, categoryID := "in", options.InCategoryID
+	if line.Amount < 0 {
+		transactionType, categoryID = "out", options.OutCategoryID
+	}
+	if categoryID == 0 {
+		return nil, fmt.Sprintf("tidak ada kategori untuk transaksi %s", transactionTypeName(transactionType))
+	}
+
+	reference := line.Reference
+	description := line.Description
+	if description == "" {
+		description = "Mutasi bank " + reference
+	}
+	return &domain.CashTransaction{
+		TransactionDate: line.Date,
+		AccountID:       options.AccountID,
+		Type:            transactionType,
+		Kind:            domain.TransactionKindRegular,
+		CategoryID:      categoryID,
+		Description:     strings.TrimSpace(description),
+		Amount:          line.Amount.Abs(),
+		PaymentMethod:   bankPaymentMethod,
+		BankReference:   &reference,
+		CreatedBy:       options.CreatedBy,
+	}, ""
+}
+
+func transactionTypeName(transactionType string) string {
+	if transactionType == "in" {
+		return "masuk"
+	}
+	return "keluar"
+}
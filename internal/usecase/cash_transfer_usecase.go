@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"fmt"
+	"go-project/internal/domain"
+	"go-project/internal/repository"
+	"strings"
+	"time"
+)
+
+// TransferFunds books a transfer as an "out" leg on the source account and
+// an "in" leg on the target account, linked to each other through
+// ReferenceID, in one database transaction.
+func (u *cashUsecase) TransferFunds(transfer domain.CashTransfer) (*domain.CashTransfer, error) {
+	if transfer.FromAccountID == 0 || transfer.ToAccountID == 0 {
+		return nil, ErrInvalidAccount
+	}
+	if transfer.FromAccountID == transfer.ToAccountID {
+		return nil, ErrTransferSameAccount
+	}
+	if !transfer.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	if transfer.TransactionDate.IsZero() {
+		transfer.TransactionDate = time.Now()
+	}
+	if u.isFutureDate(transfer.TransactionDate) {
+		return nil, ErrFutureTransactionDate
+	}
+
+	err := u.write(func(repo repository.CashRepository) error {
+		if err := lockAccounts(repo, transfer.FromAccountID, transfer.ToAccountID); err != nil {
+			return err
+		}
+		if err := u.checkOpenPeriod(repo, transfer.TransactionDate); err != nil {
+			return err
+		}
+		from, err := activeAccount(repo, transfer.FromAccountID)
+		if err != nil {
+			return err
+		}
+		to, err := activeAccount(repo, transfer.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		category, err := systemCategory(repo, domain.SystemCategoryTransfer)
+		if err != nil {
+			return err
+		}
+
+		description := strings.TrimSpace(transfer.Description)
+		if description == "" {
+			description = fmt.Sprintf("Transfer %s ke %s", from.Name, to.Name)
+		}
+
+		out := domain.CashTransaction{
+			TransactionDate: transfer.TransactionDate,
+			AccountID:       from.ID,
+			Type:            "out",
+			Kind:            domain.TransactionKindTransfer,
+			CategoryID:      category.ID,
+			Description:     description,
+			Amount:          transfer.Amount,
+			PaymentMethod:   "transfer",
+			CreatedBy:       transfer.CreatedBy,
+		}
+		if err := repo.CreateTransaction(&out); err != nil {
+			return err
+		}
+
+		in := out
+		in.ID = 0
+		in.AccountID = to.ID
+		in.Type = "in"
+		in.ReferenceID = &out.ID
+		if err := repo.CreateTransaction(&in); err != nil {
+			return err
+		}
+
+		out.ReferenceID = &in.ID
+		if err := repo.UpdateTransaction(&out); err != nil {
+			return err
+		}
+
+		if err := u.postBalance(repo, out, 1); err != nil {
+			return err
+		}
+		if err := u.postBalance(repo, in, 1); err != nil {
+			return err
+		}
+
+		transfer.Description = description
+		transfer.Out = &out
+		transfer.In = &in
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func activeAccount(repo repository.CashRepository, accountID uint) (*domain.CashAccount, error) {
+	account, err := repo.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrInvalidAccount
+	}
+	if account.IsArchived() {
+		return nil, ErrAccountArchived
+	}
+	return account, nil
+}
+
+// systemCategory returns the category marked with the given system key,
+// creating it when the migration has not, and bringing it back when it was
+// archived before system categories were protected from that.
+func systemCategory(repo repository.CashRepository, key string) (*domain.CashCategory, error) {
+	category, err := repo.GetSystemCategory(key)
+	if err != nil {
+		return nil, err
+	}
+	if category != nil {
+		if category.IsArchived() {
+			category.ArchivedAt = nil
+			if err := repo.UpdateCategory(category); err != nil {
+				return nil, err
+			}
+		}
+		return category, nil
+	}
+
+	created := domain.SystemCategories[key]
+	created.SystemKey = &key
+	category = &created
+	if err := repo.CreateCategory(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+var (
+	ErrTransferSameAccount = fmt.Errorf("akun asal dan akun tujuan transfer tidak boleh sama")
+)
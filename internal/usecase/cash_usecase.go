@@ -4,73 +4,603 @@ import (
 	"fmt"
 	"go-project/internal/domain"
 	"go-project/internal/repository"
+	"sort"
 	"time"
 )
 
 type CashUsecase interface {
 	RecordTransaction(transaction domain.CashTransaction) error
-	GetReport(start, end time.Time) ([]domain.CashTransaction, error)
-	CalculateDailyBalance(date time.Time) (*domain.CashBalance, error)
-	GetCategories() ([]domain.CashCategory, error)
+	UpdateTransaction(id uint, input domain.CashTransaction) (*domain.CashTransaction, error)
+	VoidTransaction(id uint, userID uint, reason string) (*domain.CashTransaction, error)
+	GetReport(accountID uint, start, end time.Time) ([]domain.CashTransaction, error)
+	ListTransactions(filter domain.TransactionFilter, cursor string) (*domain.TransactionPage, error)
+	ImportTransactions(rows []domain.ImportRow, accountID, userID uint, dryRun bool) (*domain.ImportResult, error)
+	ImportStatement(statement *domain.BankStatement, options domain.StatementImport) (*domain.StatementImportResult, error)
+	ExportTransactions(filter domain.TransactionFilter, fn func(domain.CashTransaction) error) error
+	CreateReconciliation(input domain.Reconciliation) (*domain.Reconciliation, error)
+	LoadStatement(id uint, statement *domain.BankStatement) (*domain.ReconciliationReport, error)
+	GetReconciliations(accountID uint) ([]domain.Reconciliation, error)
+	GetReconciliationReport(id uint) (*domain.ReconciliationReport, error)
+	MatchReconciliation(id uint, options domain.MatchOptions) (*domain.ReconciliationReport, error)
+	MatchReconciliationLine(id, lineID, transactionID uint) (*domain.ReconciliationReport, error)
+	UnmatchReconciliationLine(id, lineID uint) (*domain.ReconciliationReport, error)
+	CompleteReconciliation(id, userID uint) (*domain.ReconciliationReport, error)
+	CountCash(count domain.CashCount) (*domain.CashCount, error)
+	GetCashCounts(accountID uint, start, end time.Time) ([]domain.CashCount, error)
+	GetCashCount(id uint) (*domain.CashCount, error)
+	ConfirmCashCount(id, userID uint) (*domain.CashCount, error)
+	ClosePeriod(start, end time.Time, userID uint, note string) (*domain.PeriodClose, error)
+	ReopenPeriod(id, userID uint, reason string) (*domain.PeriodClose, error)
+	GetPeriodCloses() ([]domain.PeriodClose, error)
+	GetPeriodClose(id uint) (*domain.PeriodClose, error)
+	GetCashBook(accountID uint, start, end time.Time) (*domain.CashBook, error)
+	GetSummaryReport(accountID uint, start, end time.Time, groupBy string, byCategory bool) (*domain.SummaryReport, error)
+	CalculateDailyBalance(accountID uint, date time.Time) (*domain.CashBalance, error)
+	SetOpeningBalance(accountID uint, amount domain.Money) (*domain.CashAccount, error)
+	RecalculateBalances(accountID uint, from time.Time) error
+	GetAccounts(includeArchived bool) ([]domain.CashAccount, error)
+	CreateAccount(account domain.CashAccount) (*domain.CashAccount, error)
+	UpdateAccount(id uint, input domain.CashAccount) (*domain.CashAccount, error)
+	ArchiveAccount(id uint) (*domain.CashAccount, error)
+	TransferFunds(transfer domain.CashTransfer) (*domain.CashTransfer, error)
+	GetCategories(includeArchived bool) ([]domain.CashCategory, error)
+	CreateCategory(category domain.CashCategory) (*domain.CashCategory, error)
+	UpdateCategory(id uint, input domain.CashCategory) (*domain.CashCategory, error)
+	ArchiveCategory(id uint) (*domain.CashCategory, error)
 }
 
 type cashUsecase struct {
-	repo repository.CashRepository
+	repo     repository.CashRepository
+	uow      repository.UnitOfWork
+	location *time.Location
 }
 
-func NewCashUsecase(repo repository.CashRepository) CashUsecase {
-	return &cashUsecase{repo: repo}
+func NewCashUsecase(repo repository.CashRepository, uow repository.UnitOfWork, location *time.Location) CashUsecase {
+	if location == nil {
+		location = time.Local
+	}
+	return &cashUsecase{repo: repo, uow: uow, location: location}
 }
 
 func (u *cashUsecase) RecordTransaction(transaction domain.CashTransaction) error {
 	if transaction.Type != "in" && transaction.Type != "out" {
 		return ErrInvalidTransactionType
 	}
+	if !transaction.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
 
-	transaction.TransactionDate = time.Now()
-	if err := u.repo.CreateTransaction(&transaction); err != nil {
-		return err
+	if transaction.TransactionDate.IsZero() {
+		transaction.TransactionDate = time.Now()
+	}
+	if u.isFutureDate(transaction.TransactionDate) {
+		return ErrFutureTransactionDate
+	}
+	// Only what a caller may choose is kept: the ID, links to other rows
+	// and the void and reconciliation state belong to the server.
+	transaction = domain.CashTransaction{
+		TransactionDate: transaction.TransactionDate,
+		AccountID:       transaction.AccountID,
+		Type:            transaction.Type,
+		Kind:            domain.TransactionKindRegular,
+		CategoryID:      transaction.CategoryID,
+		Description:     transaction.Description,
+		Amount:          transaction.Amount,
+		PaymentMethod:   transaction.PaymentMethod,
+		CreatedBy:       transaction.CreatedBy,
 	}
 
-	date := transaction.TransactionDate.Truncate(24 * time.Hour)
-	balance, _ := u.repo.GetBalanceByDate(date)
-	if balance == nil {
-		balance = &domain.CashBalance{
-			Date: date,
+	return u.write(func(repo repository.CashRepository) error {
+		if err := lockAccounts(repo, transaction.AccountID); err != nil {
+			return err
+		}
+		if err := u.checkOpenPeriod(repo, transaction.TransactionDate); err != nil {
+			return err
+		}
+		if err := checkAccount(repo, transaction.AccountID, 0); err != nil {
+			return err
+		}
+		if err := checkCategory(repo, transaction.CategoryID, transaction.Type, 0); err != nil {
+			return err
+		}
+		if err := repo.CreateTransaction(&transaction); err != nil {
+			return err
 		}
+		return u.postBalance(repo, transaction, 1)
+	})
+}
+
+func (u *cashUsecase) UpdateTransaction(id uint, input domain.CashTransaction) (*domain.CashTransaction, error) {
+	if input.Type != "in" && input.Type != "out" {
+		return nil, ErrInvalidTransactionType
+	}
+	if !input.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	if !input.TransactionDate.IsZero() && u.isFutureDate(input.TransactionDate) {
+		return nil, ErrFutureTransactionDate
 	}
 
-	if transaction.Type == "in" {
-		balance.TotalIn += transaction.Amount
-	} else {
-		balance.TotalOut += transaction.Amount
+	var transaction *domain.CashTransaction
+	err := u.write(func(repo repository.CashRepository) error {
+		if err := lockTransactionAccounts(repo, id, input.AccountID); err != nil {
+			return err
+		}
+		var err error
+		transaction, err = editableTransaction(repo, id)
+		if err != nil {
+			return err
+		}
+		if transaction.IsTransfer() {
+			return ErrTransferNotEditable
+		}
+		accountID := input.AccountID
+		if accountID == 0 {
+			accountID = transaction.AccountID
+		}
+		// Already held unless the transaction moved before its row lock.
+		if err := lockAccounts(repo, transaction.AccountID, accountID); err != nil {
+			return err
+		}
+		if err := u.checkOpenPeriod(repo, transaction.TransactionDate, input.TransactionDate); err != nil {
+			return err
+		}
+		if err := checkAccount(repo, accountID, transaction.AccountID); err != nil {
+			return err
+		}
+		if err := checkCategory(repo, input.CategoryID, input.Type, transaction.CategoryID); err != nil {
+			return err
+		}
+
+		if err := u.postBalance(repo, *transaction, -1); err != nil {
+			return err
+		}
+
+		if !input.TransactionDate.IsZero() {
+			transaction.TransactionDate = input.TransactionDate
+		}
+		transaction.AccountID = accountID
+		transaction.Type = input.Type
+		transaction.CategoryID = input.CategoryID
+		transaction.Description = input.Description
+		transaction.Amount = input.Amount
+		transaction.PaymentMethod = input.PaymentMethod
+		transaction.Category = nil
+		if err := repo.UpdateTransaction(transaction); err != nil {
+			return err
+		}
+
+		return u.postBalance(repo, *transaction, 1)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return transaction, nil
+}
 
-	balance.ClosingBalance = balance.OpeningBalance + balance.TotalIn - balance.TotalOut
-	balance.CalculatedAt = time.Now()
+// VoidTransaction keeps the original row and books a reversal with the
+// negated amount on the same day, so the history stays visible. Voiding
+// either leg of a transfer voids both legs.
+func (u *cashUsecase) VoidTransaction(id uint, userID uint, reason string) (*domain.CashTransaction, error) {
+	var reversal *domain.CashTransaction
+	err := u.write(func(repo repository.CashRepository) error {
+		if err := lockTransactionAccounts(repo, id); err != nil {
+			return err
+		}
+		transaction, err := editableTransaction(repo, id)
+		if err != nil {
+			return err
+		}
+
+		if !transaction.IsTransfer() || transaction.ReferenceID == nil {
+			if err := lockAccounts(repo, transaction.AccountID); err != nil {
+				return err
+			}
+			reversal, err = u.voidTransaction(repo, transaction, userID, reason)
+			return err
+		}
+
+		other, err := editableTransaction(repo, *transaction.ReferenceID)
+		if err != nil {
+			return err
+		}
+		if err := lockAccounts(repo, transaction.AccountID, other.AccountID); err != nil {
+			return err
+		}
+		if _, err := u.voidTransaction(repo, other, userID, reason); err != nil {
+			return err
+		}
+		reversal, err = u.voidTransaction(repo, transaction, userID, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+func (u *cashUsecase) voidTransaction(repo repository.CashRepository, transaction *domain.CashTransaction, userID uint, reason string) (*domain.CashTransaction, error) {
+	if err := u.checkOpenPeriod(repo, transaction.TransactionDate); err != nil {
+		return nil, err
+	}
+	reversal := domain.CashTransaction{
+		TransactionDate: transaction.TransactionDate,
+		AccountID:       transaction.AccountID,
+		Type:            transaction.Type,
+		Kind:            transaction.Kind,
+		CategoryID:      transaction.CategoryID,
+		Description:     fmt.Sprintf("Pembatalan transaksi #%d", transaction.ID),
+		Amount:          -transaction.Amount,
+		PaymentMethod:   transaction.PaymentMethod,
+		ReversalOfID:    &transaction.ID,
+		CreatedBy:       userID,
+	}
+	if reason != "" {
+		reversal.Description += ": " + reason
+	}
+	if err := repo.CreateTransaction(&reversal); err != nil {
+		return nil, err
+	}
 
-	return u.repo.SaveOrUpdateBalance(balance)
+	now := time.Now()
+	transaction.VoidedAt = &now
+	transaction.VoidedBy = &userID
+	transaction.VoidReason = reason
+	transaction.Category = nil
+	if err := repo.UpdateTransaction(transaction); err != nil {
+		return nil, err
+	}
+
+	if err := u.postBalance(repo, reversal, 1); err != nil {
+		return nil, err
+	}
+	return &reversal, nil
 }
 
-func (u *cashUsecase) GetReport(start, end time.Time) ([]domain.CashTransaction, error) {
-	return u.repo.GetTransactions(start, end)
+// GetReport returns the transactions from the start day up to and
+// including the whole end day; accountID 0 covers every account.
+func (u *cashUsecase) GetReport(accountID uint, start, end time.Time) ([]domain.CashTransaction, error) {
+	return u.repo.GetTransactions(accountID, u.bookDay(start), u.bookDay(end).AddDate(0, 0, 1))
 }
 
-func (u *cashUsecase) CalculateDailyBalance(date time.Time) (*domain.CashBalance, error) {
-	balance, _ := u.repo.GetBalanceByDate(date)
+// CalculateDailyBalance returns the account's balance on date. With
+// accountID 0 it adds up the balances of every account, archived ones
+// included as they may still hold money, like the reports do; transfers
+// between accounts cancel out there, so they are left out of the totals.
+func (u *cashUsecase) CalculateDailyBalance(accountID uint, date time.Time) (*domain.CashBalance, error) {
+	date = u.bookDay(date)
+	if accountID != 0 {
+		if err := checkAccountExists(u.repo, accountID); err != nil {
+			return nil, err
+		}
+		return u.accountBalance(accountID, date)
+	}
+
+	accounts, err := u.repo.GetAllAccounts(true)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &domain.CashBalance{Date: date, CalculatedAt: time.Now()}
+	for _, account := range accounts {
+		balance, err := u.accountBalance(account.ID, date)
+		if err != nil {
+			return nil, err
+		}
+		total.OpeningBalance += balance.OpeningBalance
+		total.TotalIn += balance.TotalIn
+		total.TotalOut += balance.TotalOut
+		total.ClosingBalance += balance.ClosingBalance
+	}
+
+	transferIn, transferOut, err := u.repo.SumTransactionsByKind(0, domain.TransactionKindTransfer, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	total.TotalIn -= transferIn
+	total.TotalOut -= transferOut
+	return total, nil
+}
+
+func (u *cashUsecase) accountBalance(accountID uint, date time.Time) (*domain.CashBalance, error) {
+	balance, err := u.repo.GetBalanceByDate(accountID, date)
+	if err != nil {
+		return nil, err
+	}
+
 	if balance == nil {
-		balance = &domain.CashBalance{Date: date}
+		opening, err := u.repo.GetOpeningBalance(accountID, date)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.CashBalance{
+			AccountID:      accountID,
+			Date:           date,
+			OpeningBalance: opening,
+			ClosingBalance: opening,
+			CalculatedAt:   time.Now(),
+		}, nil
 	}
+
 	balance.ClosingBalance = balance.OpeningBalance + balance.TotalIn - balance.TotalOut
-	balance.CalculatedAt = time.Now()
-	_ = u.repo.SaveOrUpdateBalance(balance)
 	return balance, nil
 }
 
-func (u *cashUsecase) GetCategories() ([]domain.CashCategory, error) {
-	return u.repo.GetAllCategories()
+// SetOpeningBalance sets the money held in the account before its first
+// day and shifts every stored day of the account by the difference.
+func (u *cashUsecase) SetOpeningBalance(accountID uint, amount domain.Money) (*domain.CashAccount, error) {
+	if amount < 0 {
+		return nil, ErrNegativeOpeningBalance
+	}
+
+	var account *domain.CashAccount
+	err := u.write(func(repo repository.CashRepository) error {
+		if err := lockAccounts(repo, accountID); err != nil {
+			return err
+		}
+
+		var err error
+		account, err = repo.GetAccountByID(accountID)
+		if err != nil {
+			return err
+		}
+		if account == nil {
+			return ErrAccountNotFound
+		}
+		closed, err := repo.GetClosedPeriods()
+		if err != nil {
+			return err
+		}
+		if len(closed) > 0 {
+			return ErrOpeningBalanceLocked
+		}
+
+		diff := amount - account.OpeningBalance
+		account.OpeningBalance = amount
+		if err := repo.UpdateAccount(account); err != nil {
+			return err
+		}
+
+		return repo.ShiftBalancesFrom(accountID, time.Time{}, diff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// RecalculateBalances rebuilds every day from the given date onwards out of
+// the stored transactions, chaining each closing balance into the next
+// day's opening balance. accountID 0 rebuilds every account. Closed periods
+// are left as they were closed; the days after one open with its stored
+// closing balance.
+func (u *cashUsecase) RecalculateBalances(accountID uint, from time.Time) error {
+	from = u.bookDay(from)
+
+	accountIDs := []uint{accountID}
+	if accountID == 0 {
+		accounts, err := u.repo.GetAllAccounts(true)
+		if err != nil {
+			return err
+		}
+		accountIDs = accountIDs[:0]
+		for _, account := range accounts {
+			accountIDs = append(accountIDs, account.ID)
+		}
+	} else if err := checkAccountExists(u.repo, accountID); err != nil {
+		return err
+	}
+
+	for _, id := range accountIDs {
+		err := u.write(func(repo repository.CashRepository) error {
+			if err := lockAccounts(repo, id); err != nil {
+				return err
+			}
+			return u.recalculateBalances(repo, id, from)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recalculateBalances rebuilds the open days of an account from the given
+// date onwards, skipping the days of closed periods.
+func (u *cashUsecase) recalculateBalances(repo repository.CashRepository, accountID uint, from time.Time) error {
+	closed, err := repo.GetClosedPeriods()
+	if err != nil {
+		return err
+	}
+	for _, days := range u.openRanges(closed, from) {
+		if err := u.rebuildDays(repo, accountID, days); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildDays rebuilds the stored days of an account in span, opening with
+// the closing balance of the last day before it.
+func (u *cashUsecase) rebuildDays(repo repository.CashRepository, accountID uint, span dayRange) error {
+	opening, err := repo.GetOpeningBalance(accountID, span.start)
+	if err != nil {
+		return err
+	}
+
+	balances, err := repo.GetBalancesFrom(accountID, span.start)
+	if err != nil {
+		return err
+	}
+	if !span.end.IsZero() {
+		end := span.end.Format("2006-01-02")
+		for i, balance := range balances {
+			if balance.Date.Format("2006-01-02") >= end {
+				balances = balances[:i]
+				break
+			}
+		}
+	}
+
+	end := span.end
+	if end.IsZero() {
+		end = time.Now()
+		if len(balances) > 0 && balances[len(balances)-1].Date.After(end) {
+			end = balances[len(balances)-1].Date
+		}
+		end = u.bookDay(end).AddDate(0, 0, 1)
+	}
+	transactions, err := repo.GetTransactions(accountID, span.start, end)
+	if err != nil {
+		return err
+	}
+
+	days := make(map[string]*domain.CashBalance)
+	for i := range balances {
+		balances[i].TotalIn = 0
+		balances[i].TotalOut = 0
+		days[balances[i].Date.Format("2006-01-02")] = &balances[i]
+	}
+	for _, transaction := range transactions {
+		date := u.bookDay(transaction.TransactionDate)
+		key := date.Format("2006-01-02")
+		balance, ok := days[key]
+		if !ok {
+			balance = &domain.CashBalance{AccountID: accountID, Date: date}
+			days[key] = balance
+		}
+		if transaction.Type == "in" {
+			balance.TotalIn += transaction.Amount
+		} else {
+			balance.TotalOut += transaction.Amount
+		}
+	}
+
+	keys := make([]string, 0, len(days))
+	for key := range days {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	now := time.Now()
+	for _, key := range keys {
+		balance := days[key]
+		balance.OpeningBalance = opening
+		balance.ClosingBalance = opening + balance.TotalIn - balance.TotalOut
+		balance.CalculatedAt = now
+		if err := repo.SaveOrUpdateBalance(balance); err != nil {
+			return err
+		}
+		opening = balance.ClosingBalance
+	}
+	return nil
+}
+
+// write runs fn in one database transaction. Callers take the balance
+// locks of the accounts they post to with lockAccounts.
+func (u *cashUsecase) write(fn func(repo repository.CashRepository) error) error {
+	return u.uow.Do(func(repos repository.Repositories) error {
+		return fn(repos.Cash)
+	})
+}
+
+// lockAccounts takes the balance locks of the given accounts in id order,
+// so writers touching the same accounts cannot deadlock each other.
+func lockAccounts(repo repository.CashRepository, accountIDs ...uint) error {
+	ids := append([]uint(nil), accountIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i, id := range ids {
+		if id == 0 || (i > 0 && ids[i-1] == id) {
+			continue
+		}
+		if err := repo.LockBalances(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockTransactionAccounts takes the balance locks of the account a
+// transaction is booked on, of the other leg's account for a transfer, and
+// of the given accounts. Writers lock accounts before rows, as
+// RecordTransaction and CompleteReconciliation do, so it is called before
+// editableTransaction; voids of the two legs of one transfer then wait for
+// each other instead of each holding one leg's row.
+func lockTransactionAccounts(repo repository.CashRepository, id uint, accountIDs ...uint) error {
+	transaction, err := repo.GetTransactionByID(id)
+	if err != nil {
+		return err
+	}
+	if transaction == nil {
+		return ErrTransactionNotFound
+	}
+	accountIDs = append(accountIDs, transaction.AccountID)
+	if transaction.IsTransfer() && transaction.ReferenceID != nil {
+		other, err := repo.GetTransactionByID(*transaction.ReferenceID)
+		if err != nil {
+			return err
+		}
+		if other != nil {
+			accountIDs = append(accountIDs, other.AccountID)
+		}
+	}
+	return lockAccounts(repo, accountIDs...)
+}
+
+// editableTransaction loads a transaction for a change and locks its row,
+// so two edits or voids of the same transaction run one after another.
+func editableTransaction(repo repository.CashRepository, id uint) (*domain.CashTransaction, error) {
+	transaction, err := repo.GetTransactionForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+	if transaction.IsVoided() {
+		return nil, ErrTransactionVoided
+	}
+	if transaction.IsReversal() {
+		return nil, ErrTransactionIsReversal
+	}
+	if transaction.IsReconciled() {
+		return nil, ErrTransactionReconciled
+	}
+	return transaction, nil
+}
+
+// postBalance books the transaction into its day's balance; sign -1 takes
+// a previously booked transaction back out.
+func (u *cashUsecase) postBalance(repo repository.CashRepository, transaction domain.CashTransaction, sign domain.Money) error {
+	date := u.bookDay(transaction.TransactionDate)
+	amount := sign * transaction.Amount
+	if transaction.Type == "in" {
+		return repo.ApplyBalanceDelta(transaction.AccountID, date, amount, 0)
+	}
+	return repo.ApplyBalanceDelta(transaction.AccountID, date, 0, amount)
+}
+
+// bookDay returns midnight of the day t falls on in the configured time
+// zone, which is the bucket its balance is kept under.
+func (u *cashUsecase) bookDay(t time.Time) time.Time {
+	year, month, day := t.In(u.location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, u.location)
+}
+
+func (u *cashUsecase) isFutureDate(t time.Time) bool {
+	return u.bookDay(t).After(u.bookDay(time.Now()))
 }
 
 var (
 	ErrInvalidTransactionType = fmt.Errorf("jenis transaksi tidak valid: harus 'masuk' atau 'keluar'")
+	ErrTransactionNotFound    = fmt.Errorf("transaksi tidak ditemukan")
+	ErrTransactionVoided      = fmt.Errorf("transaksi sudah dibatalkan")
+	ErrTransactionIsReversal  = fmt.Errorf("transaksi pembatalan tidak dapat diubah")
+	ErrFutureTransactionDate  = fmt.Errorf("tanggal transaksi tidak boleh melewati hari ini")
+	ErrInvalidAmount          = fmt.Errorf("nominal transaksi harus lebih dari 0")
+	ErrInvalidCategory        = fmt.Errorf("kategori transaksi tidak ditemukan")
+	ErrCategoryArchived       = fmt.Errorf("kategori sudah diarsipkan")
+	ErrCategoryTypeMismatch   = fmt.Errorf("kategori tidak dapat dipakai untuk jenis transaksi ini")
+	ErrNegativeOpeningBalance = fmt.Errorf("saldo awal tidak boleh negatif")
+	ErrTransferNotEditable    = fmt.Errorf("transaksi transfer tidak dapat diubah; batalkan lalu buat transfer baru")
 )
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-project/internal/auth"
+	"go-project/internal/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSecurityEventLimit = 50
+	maxSecurityEventLimit     = 200
+)
+
+// dummyPasswordHash is checked against when the email is unknown, so a
+// failed login takes as long whether or not the account exists.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	return auth.HashPassword("not a real password")
+})
+
+// LoginLockedError is returned while logins for the account or the client
+// IP are locked after too many failures. It matches ErrLoginLocked.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error()
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+type throttleKey struct {
+	scope string
+	key   string
+}
+
+// throttleKeys are what a login attempt counts against: the email that was
+// tried, existing or not, and the client IP.
+func throttleKeys(email string, client domain.ClientInfo) []throttleKey {
+	keys := []throttleKey{{domain.ThrottleAccount, strings.ToLower(strings.TrimSpace(email))}}
+	if client.IP != "" {
+		keys = append(keys, throttleKey{domain.ThrottleIP, client.IP})
+	}
+	return keys
+}
+
+// checkLoginLock refuses the attempt while the account or the IP is
+// locked, and logs the refusal.
+func (uc *userUsecase) checkLoginLock(email string, client domain.ClientInfo) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, k := range throttleKeys(email, client) {
+		throttle, err := uc.securityRepository.GetLoginThrottle(k.scope, k.key)
+		if err != nil {
+			return err
+		}
+		if throttle != nil && throttle.RetryAfter(now) > wait {
+			wait = throttle.RetryAfter(now)
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+	if err := uc.logSecurityEvent(domain.EventLoginBlocked, nil, email, client, ""); err != nil {
+		return err
+	}
+	return &LoginLockedError{RetryAfter: wait}
+}
+
+// failLogin logs a failed attempt and counts it against the account and
+// the IP. Past the free attempts each further failure locks logins for
+// twice as long as the one before, up to the configured maximum.
+func (uc *userUsecase) failLogin(eventType string, userID *uint, email string, client domain.ClientInfo) error {
+	if err := uc.logSecurityEvent(eventType, userID, email, client, ""); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, k := range throttleKeys(email, client) {
+		throttle, err := uc.securityRepository.RecordLoginFailure(k.scope, k.key, now, now.Add(-uc.config.FailureWindow))
+		if err != nil {
+			return err
+		}
+		free := uc.config.AccountFreeAttempts
+		if k.scope == domain.ThrottleIP {
+			free = uc.config.IPFreeAttempts
+		}
+		if throttle.Failures <= free {
+			continue
+		}
+
+		lockout := uc.lockoutDuration(throttle.Failures - free)
+		if err := uc.securityRepository.LockLogins(k.scope, k.key, now.Add(lockout)); err != nil {
+			return err
+		}
+		detail := fmt.Sprintf("%s %s locked for %s after %d failed attempts", k.scope, k.key, lockout, throttle.Failures)
+		if err := uc.logSecurityEvent(domain.EventLoginLocked, userID, email, client, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockoutDuration is the lockout after the nth failure past the free
+// attempts: the base duration, doubled for every further failure.
+func (uc *userUsecase) lockoutDuration(n int) time.Duration {
+	lockout := uc.config.LockoutBase
+	for i := 1; i < n && lockout < uc.config.LockoutMax; i++ {
+		lockout *= 2
+	}
+	return min(lockout, uc.config.LockoutMax)
+}
+
+// succeedLogin logs a successful login and forgets the account's failed
+// attempts. Those of the IP are kept, so logging into one account cannot
+// clear the count of guesses at others.
+func (uc *userUsecase) succeedLogin(user domain.User, client domain.ClientInfo) error {
+	if err := uc.securityRepository.ResetLoginThrottle(domain.ThrottleAccount, strings.ToLower(strings.TrimSpace(user.Email))); err != nil {
+		return err
+	}
+	return uc.logSecurityEvent(domain.EventLoginSuccess, &user.ID, user.Email, client, "")
+}
+
+func (uc *userUsecase) logSecurityEvent(eventType string, userID *uint, email string, client domain.ClientInfo, detail string) error {
+	return uc.securityRepository.CreateSecurityEvent(&domain.SecurityEvent{
+		Type:      eventType,
+		UserID:    userID,
+		Email:     truncateText(email, 255),
+		IP:        truncateText(client.IP, 64),
+		UserAgent: truncateText(client.UserAgent, 255),
+		Detail:    truncateText(detail, 255),
+	})
+}
+
+// GetSecurityEvents lists the security event log, newest first.
+func (uc *userUsecase) GetSecurityEvents(filter domain.SecurityEventFilter) ([]domain.SecurityEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSecurityEventLimit
+	}
+	if filter.Limit > maxSecurityEventLimit {
+		filter.Limit = maxSecurityEventLimit
+	}
+	return uc.securityRepository.GetSecurityEvents(filter)
+}
+
+// truncateText cuts value to size characters to fit its column.
+func truncateText(value string, size int) string {
+	runes := []rune(value)
+	if len(runes) <= size {
+		return value
+	}
+	return string(runes[:size])
+}
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrLoginLocked        = errors.New("too many failed login attempts; try again later")
+)
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"errors"
+	"go-project/internal/domain"
+	"testing"
+	"time"
+)
+
+// fakeSecurityRepository keeps throttles and events in memory, counting
+// failures the way securityRepository does in SQL.
+type fakeSecurityRepository struct {
+	throttles map[throttleKey]*domain.LoginThrottle
+	events    []domain.SecurityEvent
+}
+
+func newFakeSecurityRepository() *fakeSecurityRepository {
+	return &fakeSecurityRepository{throttles: make(map[throttleKey]*domain.LoginThrottle)}
+}
+
+func (r *fakeSecurityRepository) GetLoginThrottle(scope, key string) (*domain.LoginThrottle, error) {
+	throttle, ok := r.throttles[throttleKey{scope, key}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *throttle
+	return &copied, nil
+}
+
+func (r *fakeSecurityRepository) RecordLoginFailure(scope, key string, now, windowStart time.Time) (domain.LoginThrottle, error) {
+	throttle, ok := r.throttles[throttleKey{scope, key}]
+	if !ok {
+		throttle = &domain.LoginThrottle{Scope: scope, Key: key}
+		r.throttles[throttleKey{scope, key}] = throttle
+	}
+	if throttle.LastFailureAt.Before(windowStart) {
+		throttle.Failures = 0
+	}
+	throttle.Failures++
+	throttle.LastFailureAt = now
+	return *throttle, nil
+}
+
+func (r *fakeSecurityRepository) LockLogins(scope, key string, until time.Time) error {
+	if throttle, ok := r.throttles[throttleKey{scope, key}]; ok {
+		throttle.LockedUntil = &until
+	}
+	return nil
+}
+
+func (r *fakeSecurityRepository) ResetLoginThrottle(scope, key string) error {
+	delete(r.throttles, throttleKey{scope, key})
+	return nil
+}
+
+func (r *fakeSecurityRepository) CreateSecurityEvent(event *domain.SecurityEvent) error {
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *fakeSecurityRepository) GetSecurityEvents(filter domain.SecurityEventFilter) ([]domain.SecurityEvent, error) {
+	return r.events, nil
+}
+
+func (r *fakeSecurityRepository) countEvents(eventType string) int {
+	count := 0
+	for _, event := range r.events {
+		if event.Type == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+var testGuardConfig = AuthConfig{
+	AccountFreeAttempts: 3,
+	IPFreeAttempts:      5,
+	FailureWindow:       15 * time.Minute,
+	LockoutBase:         time.Minute,
+	LockoutMax:          10 * time.Minute,
+}
+
+func TestLockoutDuration(t *testing.T) {
+	uc := &userUsecase{config: testGuardConfig}
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{4, 8 * time.Minute},
+		{5, 10 * time.Minute},
+		{50, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := uc.lockoutDuration(tt.n); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLoginThrottleRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+	tests := []struct {
+		name        string
+		lockedUntil *time.Time
+		want        time.Duration
+	}{
+		{"never locked", nil, 0},
+		{"lock expired", at(-time.Second), 0},
+		{"lock ends now", at(0), 0},
+		{"still locked", at(90 * time.Second), 90 * time.Second},
+	}
+	for _, tt := range tests {
+		throttle := domain.LoginThrottle{LockedUntil: tt.lockedUntil}
+		if got := throttle.RetryAfter(now); got != tt.want {
+			t.Errorf("%s: RetryAfter = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThrottleKeys(t *testing.T) {
+	keys := throttleKeys("  Budi@Example.COM ", domain.ClientInfo{IP: "10.0.0.1"})
+	want := []throttleKey{{domain.ThrottleAccount, "budi@example.com"}, {domain.ThrottleIP, "10.0.0.1"}}
+	if len(keys) != len(want) || keys[0] != want[0] || keys[1] != want[1] {
+		t.Errorf("throttleKeys = %v, want %v", keys, want)
+	}
+	if keys := throttleKeys("budi@example.com", domain.ClientInfo{}); len(keys) != 1 {
+		t.Errorf("throttleKeys without an IP = %v, want the account only", keys)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	repo := newFakeSecurityRepository()
+	uc := &userUsecase{securityRepository: repo, config: testGuardConfig}
+	client := domain.ClientInfo{IP: "10.0.0.1"}
+	const email = "budi@example.com"
+
+	for i := 1; i <= testGuardConfig.AccountFreeAttempts; i++ {
+		if err := uc.checkLoginLock(email, client); err != nil {
+			t.Fatalf("attempt %d refused: %v", i, err)
+		}
+		if err := uc.failLogin(domain.EventLoginFailure, nil, email, client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := uc.checkLoginLock(email, client); err != nil {
+		t.Fatalf("locked within the free attempts: %v", err)
+	}
+
+	// Every failure past the free attempts locks for twice as long.
+	for i, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+		if err := uc.failLogin(domain.EventLoginFailure, nil, email, client); err != nil {
+			t.Fatal(err)
+		}
+		err := uc.checkLoginLock(email, client)
+		var locked *LoginLockedError
+		if !errors.As(err, &locked) || !errors.Is(err, ErrLoginLocked) {
+			t.Fatalf("failure %d past the free attempts: error = %v, want a lockout", i+1, err)
+		}
+		if locked.RetryAfter <= want-time.Second || locked.RetryAfter > want {
+			t.Errorf("failure %d past the free attempts: retry after %s, want %s", i+1, locked.RetryAfter, want)
+		}
+	}
+	// Three of the account, and one of the IP on its sixth failure.
+	if got := repo.countEvents(domain.EventLoginLocked); got != 4 {
+		t.Errorf("%d lockouts logged, want 4", got)
+	}
+	if got := repo.countEvents(domain.EventLoginBlocked); got != 3 {
+		t.Errorf("%d blocked attempts logged, want 3", got)
+	}
+
+	// Another account from the same IP is locked once the IP runs out of
+	// free attempts.
+	ip := repo.throttles[throttleKey{domain.ThrottleIP, client.IP}]
+	if ip == nil || ip.Failures != 6 || ip.LockedUntil == nil {
+		t.Fatalf("IP throttle = %+v, want 6 failures and a lock", ip)
+	}
+	if err := uc.checkLoginLock("sari@example.com", client); !errors.Is(err, ErrLoginLocked) {
+		t.Errorf("other account from the locked IP: error = %v, want %v", err, ErrLoginLocked)
+	}
+	if err := uc.checkLoginLock("sari@example.com", domain.ClientInfo{IP: "10.0.0.2"}); err != nil {
+		t.Errorf("other account from another IP: error = %v, want none", err)
+	}
+}
+
+func TestLoginFailureWindow(t *testing.T) {
+	repo := newFakeSecurityRepository()
+	uc := &userUsecase{securityRepository: repo, config: testGuardConfig}
+	const email = "budi@example.com"
+
+	for i := 0; i < testGuardConfig.AccountFreeAttempts; i++ {
+		if err := uc.failLogin(domain.EventLoginFailure, nil, email, domain.ClientInfo{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The failures age out of the window before the next one.
+	throttle := repo.throttles[throttleKey{domain.ThrottleAccount, email}]
+	throttle.LastFailureAt = throttle.LastFailureAt.Add(-testGuardConfig.FailureWindow - time.Second)
+
+	if err := uc.failLogin(domain.EventLoginFailure, nil, email, domain.ClientInfo{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.checkLoginLock(email, domain.ClientInfo{}); err != nil {
+		t.Errorf("locked after the window passed: %v", err)
+	}
+	if throttle.Failures != 1 {
+		t.Errorf("failures = %d, want counting to start again", throttle.Failures)
+	}
+}
+
+func TestSucceedLoginKeepsIPFailures(t *testing.T) {
+	repo := newFakeSecurityRepository()
+	uc := &userUsecase{securityRepository: repo, config: testGuardConfig}
+	client := domain.ClientInfo{IP: "10.0.0.1"}
+
+	for i := 0; i < 2; i++ {
+		if err := uc.failLogin(domain.EventLoginFailure, nil, "Budi@example.com", client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := uc.succeedLogin(domain.User{ID: 7, Email: "budi@example.com"}, client); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := repo.throttles[throttleKey{domain.ThrottleAccount, "budi@example.com"}]; ok {
+		t.Error("account failures kept after a successful login")
+	}
+	if ip := repo.throttles[throttleKey{domain.ThrottleIP, client.IP}]; ip == nil || ip.Failures != 2 {
+		t.Errorf("IP throttle = %+v, want its 2 failures kept", ip)
+	}
+	if got := repo.countEvents(domain.EventLoginSuccess); got != 1 {
+		t.Errorf("%d successful logins logged, want 1", got)
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-project/internal/auth"
+	"go-project/internal/domain"
+	"go-project/internal/mailer"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ForgotPassword emails a single-use password reset link to the user with
+// the given address. An unknown address is not reported, so the endpoint
+// cannot be used to find out who has an account. Neither are requests
+// within the resend interval of the address or over the limit of the
+// client IP, which are dropped, nor a failure to send the email, which
+// happens in the background.
+func (uc *userUsecase) ForgotPassword(email string, client domain.ClientInfo) error {
+	allowed, err := uc.allowMail(client)
+	if err != nil || !allowed {
+		return err
+	}
+	user, err := uc.userRepository.GetUserByEmail(email)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	claimed, err := uc.userRepository.ClaimPasswordResetSend(user.ID, now, now.Add(-uc.config.ResetResendInterval))
+	if err != nil || !claimed {
+		return err
+	}
+
+	if err := uc.tokenRepository.InvalidatePasswordResets(user.ID); err != nil {
+		return err
+	}
+	token, err := auth.RandomToken(32)
+	if err != nil {
+		return err
+	}
+	err = uc.tokenRepository.CreatePasswordReset(&domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: now.Add(uc.config.ResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(uc.config.AppURL, "/"), url.QueryEscape(token))
+	uc.sendMail(mailer.Message{
+		To:      user.Email,
+		Subject: "Atur ulang kata sandi Buku Kas",
+		Body: fmt.Sprintf("Halo %s,\n\n"+
+			"Kami menerima permintaan untuk mengatur ulang kata sandi akun Anda. "+
+			"Buka tautan berikut untuk membuat kata sandi baru:\n\n%s\n\n"+
+			"Tautan ini hanya dapat dipakai sekali dan berlaku sampai %s. "+
+			"Abaikan email ini bila Anda tidak memintanya.\n",
+			user.Name, link, now.Add(uc.config.ResetTTL).Format("02-01-2006 15:04")),
+	})
+	return nil
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword. The
+// token is used up, and every session of the user is revoked, refresh
+// and access tokens alike, so others have to log in with the new password. Since the token
+// came by email, an unverified address counts as verified afterwards.
+func (uc *userUsecase) ResetPassword(token, password string) error {
+	reset, err := uc.tokenRepository.GetPasswordResetByHash(auth.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if reset == nil || reset.UsedAt != nil || time.Now().After(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	used, err := uc.tokenRepository.UsePasswordReset(reset.ID)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	user, err := uc.userRepository.GetUserByID(reset.UserID)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+	user.Password = auth.HashPassword(password)
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	if _, err := uc.userRepository.UpdateUser(user); err != nil {
+		return err
+	}
+	return uc.revokeUserSessions(user.ID)
+}
+
+var ErrInvalidResetToken = errors.New("invalid, used or expired reset token")
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"errors"
+	"go-project/internal/domain"
+	"go-project/internal/mailer"
+	"go-project/internal/repository"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeResetRepository keeps users and password reset tokens in memory,
+// claiming sends the way userRepository does in SQL.
+type fakeResetRepository struct {
+	repository.UserRepository
+	repository.TokenRepository
+	users  map[string]*domain.User
+	resets []domain.PasswordResetToken
+}
+
+func (r *fakeResetRepository) GetUserByEmail(email string) (domain.User, error) {
+	user, ok := r.users[email]
+	if !ok {
+		return domain.User{}, errors.New("record not found")
+	}
+	return *user, nil
+}
+
+func (r *fakeResetRepository) ClaimPasswordResetSend(id uint, at, sentBefore time.Time) (bool, error) {
+	for _, user := range r.users {
+		if user.ID == id && (user.PasswordResetSentAt == nil || !user.PasswordResetSentAt.After(sentBefore)) {
+			user.PasswordResetSentAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeResetRepository) InvalidatePasswordResets(userID uint) error {
+	return nil
+}
+
+func (r *fakeResetRepository) CreatePasswordReset(token *domain.PasswordResetToken) error {
+	r.resets = append(r.resets, *token)
+	return nil
+}
+
+// fakeMailer passes sent messages on, or fails every send with err.
+type fakeMailer struct {
+	sent chan mailer.Message
+	err  error
+}
+
+func (m *fakeMailer) Send(message mailer.Message) error {
+	m.sent <- message
+	return m.err
+}
+
+func TestForgotPasswordThrottle(t *testing.T) {
+	repo := &fakeResetRepository{users: map[string]*domain.User{
+		"budi@example.com": {ID: 1, Email: "budi@example.com"},
+		"sari@example.com": {ID: 2, Email: "sari@example.com"},
+	}}
+	mail := &fakeMailer{sent: make(chan mailer.Message, 10), err: errors.New("smtp down")}
+	logged := make(chan string, 10)
+	uc := &userUsecase{
+		userRepository:     repo,
+		tokenRepository:    repo,
+		securityRepository: newFakeSecurityRepository(),
+		mailer:             mail,
+		config: AuthConfig{
+			ResetTTL:            time.Hour,
+			ResetResendInterval: time.Minute,
+			MailIPLimit:         3,
+			MailIPWindow:        time.Hour,
+		},
+		logf: func(format string, args ...any) { logged <- format },
+	}
+	client := domain.ClientInfo{IP: "10.0.0.1"}
+
+	// A failed send is logged, not returned.
+	if err := uc.ForgotPassword("budi@example.com", client); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if message := <-mail.sent; message.To != "budi@example.com" || !strings.Contains(message.Body, "/reset-password?token=") {
+		t.Errorf("sent %+v, want a reset link to budi@example.com", message)
+	}
+	<-logged
+
+	// Within the resend interval of the address nothing is sent; an
+	// unknown address is not sent anything either. Both count towards
+	// the limit of the IP, after which another address gets nothing.
+	for _, email := range []string{"budi@example.com", "nobody@example.com", "sari@example.com"} {
+		if err := uc.ForgotPassword(email, client); err != nil {
+			t.Fatalf("%s: %v", email, err)
+		}
+	}
+	if len(repo.resets) != 1 {
+		t.Errorf("%d reset links created, want 1", len(repo.resets))
+	}
+
+	// Another IP is not held back by the first one's limit.
+	if err := uc.ForgotPassword("sari@example.com", domain.ClientInfo{IP: "10.0.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.resets) != 2 {
+		t.Errorf("%d reset links created, want 2", len(repo.resets))
+	}
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"errors"
+	"go-project/internal/auth"
+	"go-project/internal/domain"
+	"time"
+)
+
+// issueTokens creates an access token and a refresh token for user. An
+// empty familyID starts a new family, i.e. a new login session.
+func (uc *userUsecase) issueTokens(user domain.User, familyID string) (*domain.TokenPair, error) {
+	role, err := uc.loginRole(user)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := auth.GenerateToken(user.ID, user.Email, role, uc.config.AccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if familyID == "" {
+		if familyID, err = auth.RandomToken(24); err != nil {
+			return nil, err
+		}
+	}
+	refreshToken, err := auth.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.tokenRepository.CreateRefreshToken(&domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: auth.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(uc.config.RefreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(uc.config.AccessTTL.Seconds()),
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair and revokes
+// the old refresh token. A refresh token that was already used is taken as
+// stolen: every token of its family is revoked and the user has to log in
+// again. The new access token carries the user's current role, which is
+// also where a user who verified their email gets full access.
+func (uc *userUsecase) RefreshToken(refreshToken string) (*domain.TokenPair, error) {
+	token, err := uc.tokenRepository.GetRefreshTokenByHash(auth.HashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || time.Now().After(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	revoked := false
+	if !token.IsRevoked() {
+		if revoked, err = uc.tokenRepository.RevokeRefreshToken(token.ID); err != nil {
+			return nil, err
+		}
+	}
+	if !revoked {
+		if err := uc.tokenRepository.RevokeTokenFamily(token.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := uc.userRepository.GetUserByID(token.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	return uc.issueTokens(user, token.FamilyID)
+}
+
+// Logout revokes the access token of session and the refresh token family
+// it was given with. With allSessions every session of the user is
+// revoked, the access tokens of the other sessions included.
+func (uc *userUsecase) Logout(session domain.Session, refreshToken string, allSessions bool) error {
+	if err := uc.tokenRepository.RevokeAccessToken(session.JTI, session.ExpiresAt); err != nil {
+		return err
+	}
+
+	if allSessions {
+		if err := uc.revokeUserSessions(session.UserID); err != nil {
+			return err
+		}
+	} else if refreshToken != "" {
+		token, err := uc.tokenRepository.GetRefreshTokenByHash(auth.HashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token != nil && token.UserID == session.UserID {
+			if err := uc.tokenRepository.RevokeTokenFamily(token.FamilyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return uc.tokenRepository.DeleteExpiredTokens(time.Now())
+}
+
+// IsTokenRevoked reports whether the access token with jti was revoked on
+// its own, or issued to userID before all their tokens were revoked.
+func (uc *userUsecase) IsTokenRevoked(userID uint, jti string, issuedAt time.Time) (bool, error) {
+	revoked, err := uc.tokenRepository.IsAccessTokenRevoked(jti)
+	if err != nil || revoked {
+		return revoked, err
+	}
+	user, err := uc.userRepository.GetUserByID(userID)
+	if err != nil {
+		return false, err
+	}
+	// Token times have whole seconds only, so a token from the second of
+	// the revocation still counts as issued after it.
+	return user.TokensRevokedAt != nil && issuedAt.Before(user.TokensRevokedAt.Truncate(time.Second)), nil
+}
+
+// revokeUserSessions logs the user out everywhere: refresh tokens are
+// revoked and access tokens issued until now are refused.
+func (uc *userUsecase) revokeUserSessions(userID uint) error {
+	if err := uc.tokenRepository.RevokeUserRefreshTokens(userID); err != nil {
+		return err
+	}
+	return uc.userRepository.RevokeUserTokens(userID, time.Now())
+}
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; all sessions of this login have been revoked")
+)
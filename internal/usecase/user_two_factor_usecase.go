@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"errors"
+	"go-project/internal/auth"
+	"go-project/internal/domain"
+	"time"
+)
+
+const (
+	recoveryCodeCount = 10
+	// maxChallengeAttempts wrong codes use up a login challenge, so a
+	// six digit code cannot be guessed with one password entry.
+	maxChallengeAttempts = 5
+)
+
+// createChallenge starts the second login step for user.
+func (uc *userUsecase) createChallenge(user domain.User) (*domain.TwoFactorChallenge, error) {
+	token, err := auth.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.tokenRepository.CreateLoginChallenge(&domain.LoginChallenge{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: time.Now().Add(uc.config.ChallengeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		Challenge:         token,
+		ExpiresIn:         int(uc.config.ChallengeTTL.Seconds()),
+	}, nil
+}
+
+// CompleteLogin finishes a login that asked for a second factor, with a
+// TOTP code or an unused recovery code, and issues the tokens. A wrong code
+// counts as a failed login of the account and the client IP.
+func (uc *userUsecase) CompleteLogin(challenge, code string, client domain.ClientInfo) (*domain.TokenPair, error) {
+	pending, err := uc.tokenRepository.GetLoginChallengeByHash(auth.HashToken(challenge))
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil || pending.UsedAt != nil || time.Now().After(pending.ExpiresAt) {
+		return nil, ErrInvalidChallenge
+	}
+	user, err := uc.userRepository.GetUserByID(pending.UserID)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	if err := uc.checkLoginLock(user.Email, client); err != nil {
+		return nil, err
+	}
+	twoFactor, err := uc.twoFactorRepository.GetTwoFactor(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return nil, ErrInvalidChallenge
+	}
+
+	ok, err := uc.checkSecondFactor(*twoFactor, code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := uc.tokenRepository.FailLoginChallenge(pending.ID, maxChallengeAttempts); err != nil {
+			return nil, err
+		}
+		if err := uc.failLogin(domain.EventTwoFactorFailure, &user.ID, user.Email, client); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	used, err := uc.tokenRepository.UseLoginChallenge(pending.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidChallenge
+	}
+
+	tokens, err := uc.issueTokens(user, "")
+	if err != nil {
+		return nil, err
+	}
+	return tokens, uc.succeedLogin(user, client)
+}
+
+// checkSecondFactor accepts a TOTP code not used before and, with
+// allowRecovery, an unused recovery code, which is used up.
+func (uc *userUsecase) checkSecondFactor(twoFactor domain.TwoFactor, code string, allowRecovery bool) (bool, error) {
+	if step, ok := auth.TOTPStep(twoFactor.Secret, code, time.Now()); ok {
+		return uc.twoFactorRepository.UseTOTPStep(twoFactor.UserID, step)
+	}
+	if !allowRecovery {
+		return false, nil
+	}
+	return uc.twoFactorRepository.UseRecoveryCode(twoFactor.UserID, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+}
+
+func (uc *userUsecase) GetTwoFactorStatus(userID uint) (domain.TwoFactorStatus, error) {
+	twoFactor, err := uc.twoFactorRepository.GetTwoFactor(userID)
+	if err != nil || twoFactor == nil || !twoFactor.IsEnabled() {
+		return domain.TwoFactorStatus{}, err
+	}
+	left, err := uc.twoFactorRepository.CountRecoveryCodes(userID)
+	if err != nil {
+		return domain.TwoFactorStatus{}, err
+	}
+	return domain.TwoFactorStatus{
+		Enabled:           true,
+		EnabledAt:         twoFactor.ConfirmedAt,
+		RecoveryCodesLeft: left,
+	}, nil
+}
+
+// EnrollTwoFactor creates a new TOTP secret for the user. It takes effect
+// only after ConfirmTwoFactor; enrolling again before that replaces it.
+func (uc *userUsecase) EnrollTwoFactor(userID uint) (*domain.TwoFactorEnrollment, error) {
+	user, err := uc.userRepository.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	twoFactor, err := uc.twoFactorRepository.GetTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor != nil && twoFactor.IsEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	secret, uri, qrCode, err := auth.NewTOTPKey(uc.config.TwoFactorIssuer, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepository.SaveTwoFactorSecret(userID, secret); err != nil {
+		return nil, err
+	}
+	return &domain.TwoFactorEnrollment{Secret: secret, OTPAuthURI: uri, QRCode: qrCode}, nil
+}
+
+// ConfirmTwoFactor turns two-factor authentication on with a code from the
+// enrolled authenticator and returns the recovery codes. They are shown
+// only this once; just their hashes are stored.
+func (uc *userUsecase) ConfirmTwoFactor(userID uint, code string) ([]string, error) {
+	twoFactor, err := uc.twoFactorRepository.GetTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	if twoFactor.IsEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	step, ok := auth.TOTPStep(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := uc.twoFactorRepository.EnableTwoFactor(userID, step, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user, used or
+// not, after checking a TOTP code.
+func (uc *userUsecase) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	twoFactor, err := uc.enabledTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := uc.checkSecondFactor(*twoFactor, code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepository.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off. It asks for the
+// password and a TOTP or recovery code, so a stolen access token alone is
+// not enough.
+func (uc *userUsecase) DisableTwoFactor(userID uint, password, code string) error {
+	user, err := uc.userRepository.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if err := auth.CheckPasswordHash(password, user.Password); err != nil {
+		return ErrInvalidPassword
+	}
+	twoFactor, err := uc.enabledTwoFactor(userID)
+	if err != nil {
+		return err
+	}
+	ok, err := uc.checkSecondFactor(*twoFactor, code, true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	return uc.twoFactorRepository.DisableTwoFactor(userID)
+}
+
+func (uc *userUsecase) enabledTwoFactor(userID uint) (*domain.TwoFactor, error) {
+	twoFactor, err := uc.twoFactorRepository.GetTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return twoFactor, nil
+}
+
+// newRecoveryCodes returns fresh recovery codes and the hashes to store.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := auth.RecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = code
+		hashes[i] = auth.HashToken(auth.NormalizeRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+var (
+	ErrInvalidChallenge     = errors.New("invalid, used or expired login challenge; please log in again")
+	ErrInvalidTwoFactorCode = errors.New("invalid authentication code")
+	ErrInvalidPassword      = errors.New("invalid password")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled  = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled = errors.New("two-factor authentication has not been enrolled")
+)
@@ -5,47 +5,197 @@ import (
 	"fmt"
 	"go-project/internal/auth"
 	"go-project/internal/domain"
+	"go-project/internal/mailer"
 	"go-project/internal/repository"
+	"time"
 )
 
 type UserUsecase interface {
 	Register(user domain.User) (domain.User, error)
-	Login(email, password string) (string, error)
+	Login(email, password string, client domain.ClientInfo) (*domain.TokenPair, *domain.TwoFactorChallenge, error)
+	CompleteLogin(challenge, code string, client domain.ClientInfo) (*domain.TokenPair, error)
+	RefreshToken(refreshToken string) (*domain.TokenPair, error)
+	Logout(session domain.Session, refreshToken string, allSessions bool) error
+	IsTokenRevoked(userID uint, jti string, issuedAt time.Time) (bool, error)
+	ForgotPassword(email string, client domain.ClientInfo) error
+	ResetPassword(token, password string) error
+	VerifyEmail(token string) error
+	ResendVerification(email string, client domain.ClientInfo) error
 	GetUserByID(i uint) (domain.User, error)
 	GetUsers() ([]domain.User, error)
+	UpdateUserRole(actorID, id uint, role string) (domain.User, error)
+	GetTwoFactorStatus(userID uint) (domain.TwoFactorStatus, error)
+	EnrollTwoFactor(userID uint) (*domain.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(userID uint, code string) ([]string, error)
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	DisableTwoFactor(userID uint, password, code string) error
+	GetSecurityEvents(filter domain.SecurityEventFilter) ([]domain.SecurityEvent, error)
+}
+
+// AuthConfig sets how long issued tokens stay valid, where emailed links
+// point to and how unverified email addresses are treated.
+type AuthConfig struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	ResetTTL   time.Duration
+	AppURL     string
+
+	// ResetResendInterval is how long ForgotPassword waits before emailing
+	// the same address again.
+	ResetResendInterval time.Duration
+
+	VerifyTTL            time.Duration
+	VerifyResendInterval time.Duration
+	// UnverifiedLogin is one of the UnverifiedLogin constants.
+	UnverifiedLogin string
+
+	// TwoFactorIssuer names the app in authenticator apps; ChallengeTTL is
+	// how long the second login step may take.
+	TwoFactorIssuer string
+	ChallengeTTL    time.Duration
+
+	// Failed logins within FailureWindow of each other are counted per
+	// account and per IP. Past the free attempts every failure locks
+	// logins, starting at LockoutBase and doubling up to LockoutMax.
+	AccountFreeAttempts int
+	IPFreeAttempts      int
+	FailureWindow       time.Duration
+	LockoutBase         time.Duration
+	LockoutMax          time.Duration
+
+	// Requests that email someone are accepted MailIPLimit times per
+	// client IP, counted like failed logins within MailIPWindow. Zero
+	// turns the limit off.
+	MailIPLimit  int
+	MailIPWindow time.Duration
 }
 
 type userUsecase struct {
-	userRepository repository.UserRepository
+	userRepository      repository.UserRepository
+	tokenRepository     repository.TokenRepository
+	twoFactorRepository repository.TwoFactorRepository
+	securityRepository  repository.SecurityRepository
+	uow                 repository.UnitOfWork
+	mailer              mailer.Mailer
+	config              AuthConfig
+	logf                func(format string, args ...any)
 }
 
-func NewUserUsecase(userRepository repository.UserRepository) *userUsecase {
+func NewUserUsecase(userRepository repository.UserRepository, tokenRepository repository.TokenRepository, twoFactorRepository repository.TwoFactorRepository, securityRepository repository.SecurityRepository, uow repository.UnitOfWork, mailer mailer.Mailer, config AuthConfig, logf func(format string, args ...any)) *userUsecase {
 	return &userUsecase{
-		userRepository: userRepository,
+		userRepository:      userRepository,
+		tokenRepository:     tokenRepository,
+		twoFactorRepository: twoFactorRepository,
+		securityRepository:  securityRepository,
+		uow:                 uow,
+		mailer:              mailer,
+		config:              config,
+		logf:                logf,
+	}
+}
+
+// sendMail sends message in the background, so a request takes as long
+// whether or not it emails anyone. A failed send is only logged.
+func (uc *userUsecase) sendMail(message mailer.Message) {
+	go func() {
+		if err := uc.mailer.Send(message); err != nil {
+			uc.logf("sending %q to %s: %v", message.Subject, message.To, err)
+		}
+	}()
+}
+
+// allowMail counts a request from client that may email someone and
+// reports whether it is within the per-IP limit. Requests over the limit
+// are dropped without telling, like the ones within a resend interval.
+func (uc *userUsecase) allowMail(client domain.ClientInfo) (bool, error) {
+	if client.IP == "" || uc.config.MailIPLimit <= 0 {
+		return true, nil
+	}
+	now := time.Now()
+	throttle, err := uc.securityRepository.RecordLoginFailure(domain.ThrottleMailIP, client.IP, now, now.Add(-uc.config.MailIPWindow))
+	if err != nil {
+		return false, err
 	}
+	return throttle.Failures <= uc.config.MailIPLimit, nil
 }
 
+// Register creates a viewer account; the very first user becomes the owner,
+// so a fresh installation has someone who can hand out roles. The account
+// starts unverified and a verification link is emailed to it.
 func (uc *userUsecase) Register(user domain.User) (domain.User, error) {
 	user.Password = auth.HashPassword(user.Password)
-	return uc.userRepository.CreateUser(user)
+	user.EmailVerifiedAt = nil
+
+	// The count and the insert share a transaction under a lock, so two
+	// simultaneous first registrations cannot both become owner.
+	err := uc.uow.Do(func(repos repository.Repositories) error {
+		if err := repos.User.LockUsers(); err != nil {
+			return err
+		}
+		total, err := repos.User.CountUsers()
+		if err != nil {
+			return err
+		}
+		user.Role = domain.RoleViewer
+		if total == 0 {
+			user.Role = domain.RoleOwner
+		}
+		user, err = repos.User.CreateUser(user)
+		return err
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	// A failed send does not undo the registration; the user can ask for
+	// a new link.
+	if err := uc.sendVerification(user); err != nil {
+		uc.logf("sending the verification link to %s: %v", user.Email, err)
+	}
+	return user, nil
 }
 
-func (uc *userUsecase) Login(email, password string) (string, error) {
+// Login checks the password and issues tokens, or, for a user with
+// two-factor authentication, a challenge to complete with CompleteLogin.
+// Unknown emails and wrong passwords fail alike, and every failure counts
+// towards locking the account and the client IP.
+func (uc *userUsecase) Login(email, password string, client domain.ClientInfo) (*domain.TokenPair, *domain.TwoFactorChallenge, error) {
+	if err := uc.checkLoginLock(email, client); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := uc.userRepository.GetUserByEmail(email)
 	if err != nil {
-		return "", errors.New("user not found")
+		auth.CheckPasswordHash(password, dummyPasswordHash())
+		if err := uc.failLogin(domain.EventLoginFailure, nil, email, client); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidCredentials
 	}
-
 	if err := auth.CheckPasswordHash(password, user.Password); err != nil {
-		return "", errors.New("invalid credentials")
+		if err := uc.failLogin(domain.EventLoginFailure, &user.ID, email, client); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidCredentials
+	}
+	if _, err := uc.loginRole(user); err != nil {
+		return nil, nil, err
 	}
 
-	token, err := auth.GenerateToken(user.ID, user.Email)
+	twoFactor, err := uc.twoFactorRepository.GetTwoFactor(user.ID)
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+	if twoFactor != nil && twoFactor.IsEnabled() {
+		challenge, err := uc.createChallenge(user)
+		return nil, challenge, err
 	}
 
-	return token, nil
+	tokens, err := uc.issueTokens(user, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, nil, uc.succeedLogin(user, client)
 }
 
 func (uc *userUsecase) GetUserByID(i uint) (domain.User, error) {
@@ -64,3 +214,49 @@ func (uc *userUsecase) GetUsers() ([]domain.User, error) {
 	}
 	return users, err
 }
+
+// UpdateUserRole changes the role of user id on behalf of actorID. Only an
+// owner may grant or take away the owner role, and the last owner cannot be
+// demoted. The user's sessions are revoked, so the new role applies from
+// their next login rather than when their tokens would have expired.
+func (uc *userUsecase) UpdateUserRole(actorID, id uint, role string) (domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return domain.User{}, ErrInvalidRole
+	}
+
+	actor, err := uc.userRepository.GetUserByID(actorID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	user, err := uc.userRepository.GetUserByID(id)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if (role == domain.RoleOwner || user.Role == domain.RoleOwner) && actor.Role != domain.RoleOwner {
+		return domain.User{}, ErrOwnerRoleRequired
+	}
+	if user.Role == domain.RoleOwner && role != domain.RoleOwner {
+		owners, err := uc.userRepository.CountUsersByRole(domain.RoleOwner)
+		if err != nil {
+			return domain.User{}, err
+		}
+		if owners <= 1 {
+			return domain.User{}, ErrLastOwner
+		}
+	}
+
+	changed := user.Role != role
+	user.Role = role
+	user, err = uc.userRepository.UpdateUser(user)
+	if err != nil || !changed {
+		return user, err
+	}
+	return user, uc.revokeUserSessions(user.ID)
+}
+
+var (
+	ErrInvalidRole       = errors.New("role must be one of owner, admin, cashier or viewer")
+	ErrOwnerRoleRequired = errors.New("only an owner can grant or revoke the owner role")
+	ErrLastOwner         = errors.New("the last owner cannot be demoted")
+)
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-project/internal/auth"
+	"go-project/internal/domain"
+	"go-project/internal/mailer"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// What Login does for a user whose email is not verified yet.
+const (
+	UnverifiedLoginDeny    = "deny"
+	UnverifiedLoginLimited = "limited"
+	UnverifiedLoginAllow   = "allow"
+)
+
+// sendVerification emails user a link that verifies their address, unless
+// a link was sent less than the resend interval ago. The email is sent in
+// the background, and a failed send is only logged.
+func (uc *userUsecase) sendVerification(user domain.User) error {
+	now := time.Now()
+	claimed, err := uc.userRepository.ClaimVerificationSend(user.ID, now, now.Add(-uc.config.VerifyResendInterval))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errVerificationThrottled
+	}
+
+	token, err := auth.GenerateVerificationToken(user.ID, user.Email, uc.config.VerifyTTL)
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/auth/verify-email?token=%s", strings.TrimRight(uc.config.AppURL, "/"), url.QueryEscape(token))
+	uc.sendMail(mailer.Message{
+		To:      user.Email,
+		Subject: "Verifikasi email akun Buku Kas",
+		Body: fmt.Sprintf("Halo %s,\n\n"+
+			"Terima kasih telah mendaftar. Buka tautan berikut untuk memverifikasi alamat email Anda:\n\n%s\n\n"+
+			"Tautan ini berlaku sampai %s. "+
+			"Abaikan email ini bila Anda tidak mendaftar.\n",
+			user.Name, link, now.Add(uc.config.VerifyTTL).Format("02-01-2006 15:04")),
+	})
+	return nil
+}
+
+// VerifyEmail marks the address named by a verification link as verified.
+// Opening a link again after that is not an error.
+func (uc *userUsecase) VerifyEmail(token string) error {
+	claims, err := auth.ParseVerificationToken(token)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := uc.userRepository.GetUserByID(claims.UserID)
+	if err != nil || !strings.EqualFold(user.Email, claims.Email) {
+		return ErrInvalidVerificationToken
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+	return uc.userRepository.MarkEmailVerified(user.ID, time.Now())
+}
+
+// ResendVerification sends a new verification link. Unknown and already
+// verified addresses are not reported, like in ForgotPassword, and neither
+// is a request within the resend interval or over the limit of the client
+// IP: it is dropped silently, as refusing it would tell that the address
+// has an unverified account. A failed send is logged, not returned.
+func (uc *userUsecase) ResendVerification(email string, client domain.ClientInfo) error {
+	allowed, err := uc.allowMail(client)
+	if err != nil || !allowed {
+		return err
+	}
+	user, err := uc.userRepository.GetUserByEmail(email)
+	if err != nil || user.IsEmailVerified() {
+		return nil
+	}
+	if err := uc.sendVerification(user); err != nil && !errors.Is(err, errVerificationThrottled) {
+		return err
+	}
+	return nil
+}
+
+// loginRole is the role put in the tokens of user. Depending on the
+// configuration an unverified user cannot log in, gets read-only tokens
+// or is treated like everyone else.
+func (uc *userUsecase) loginRole(user domain.User) (string, error) {
+	if user.IsEmailVerified() {
+		return user.Role, nil
+	}
+	switch uc.config.UnverifiedLogin {
+	case UnverifiedLoginAllow:
+		return user.Role, nil
+	case UnverifiedLoginLimited:
+		return domain.RoleViewer, nil
+	}
+	return "", ErrEmailNotVerified
+}
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification link")
+	ErrEmailNotVerified         = errors.New("email address has not been verified")
+
+	errVerificationThrottled = errors.New("a verification email was sent recently")
+)
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"errors"
+	"go-project/internal/domain"
+	"go-project/internal/mailer"
+	"go-project/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeVerificationRepository holds one unverified user.
+type fakeVerificationRepository struct {
+	repository.UserRepository
+	user domain.User
+}
+
+func (r *fakeVerificationRepository) GetUserByEmail(email string) (domain.User, error) {
+	if email != r.user.Email {
+		return domain.User{}, errors.New("record not found")
+	}
+	return r.user, nil
+}
+
+func (r *fakeVerificationRepository) ClaimVerificationSend(id uint, at, sentBefore time.Time) (bool, error) {
+	if r.user.VerificationSentAt != nil && r.user.VerificationSentAt.After(sentBefore) {
+		return false, nil
+	}
+	r.user.VerificationSentAt = &at
+	return true, nil
+}
+
+func TestResendVerificationSendFailure(t *testing.T) {
+	mail := &fakeMailer{sent: make(chan mailer.Message, 1), err: errors.New("smtp down")}
+	logged := make(chan string, 1)
+	uc := &userUsecase{
+		userRepository: &fakeVerificationRepository{user: domain.User{ID: 1, Email: "budi@example.com"}},
+		mailer:         mail,
+		config:         AuthConfig{VerifyTTL: time.Hour, VerifyResendInterval: time.Minute},
+		logf:           func(format string, args ...any) { logged <- format },
+	}
+
+	if err := uc.ResendVerification("budi@example.com", domain.ClientInfo{}); err != nil {
+		t.Fatalf("error = %v, want the failed send only logged", err)
+	}
+	if message := <-mail.sent; message.To != "budi@example.com" {
+		t.Errorf("sent to %s, want budi@example.com", message.To)
+	}
+	<-logged
+}